@@ -3,6 +3,7 @@ package api
 import (
 	"Unnispick/internal/config"
 	"Unnispick/internal/domain/delivery/router"
+	"Unnispick/internal/domain/entity"
 	"Unnispick/pkg/databases"
 	"context"
 	"fmt"
@@ -10,15 +11,19 @@ import (
 	"go.uber.org/zap"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 )
 
 type App struct {
-	cfg    *config.Config
-	echo   *echo.Echo
-	router *router.Router
-	db     databases.DB
-	logger *zap.Logger
+	cfg                *config.Config
+	echo               *echo.Echo
+	router             *router.Router
+	db                 databases.DB
+	logger             *zap.Logger
+	reservationService entity.ReservationService
+	priceService       entity.ProductPriceService
 }
 
 func NewApp(
@@ -27,13 +32,17 @@ func NewApp(
 	router *router.Router,
 	db databases.DB,
 	logger *zap.Logger,
+	reservationService entity.ReservationService,
+	priceService entity.ProductPriceService,
 ) *App {
 	return &App{
-		cfg:    cfg,
-		echo:   echo,
-		router: router,
-		db:     db,
-		logger: logger,
+		cfg:                cfg,
+		echo:               echo,
+		router:             router,
+		db:                 db,
+		logger:             logger,
+		reservationService: reservationService,
+		priceService:       priceService,
 	}
 }
 
@@ -41,6 +50,19 @@ func (a *App) Start() error {
 	// Setup routes
 	a.router.Setup()
 
+	// Start background workers
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	workers.Add(2)
+	go func() {
+		defer workers.Done()
+		a.runReservationSweeper(workerCtx)
+	}()
+	go func() {
+		defer workers.Done()
+		a.runPriceScheduler(workerCtx)
+	}()
+
 	// Start server
 	go func() {
 		addr := fmt.Sprintf("%s:%d", a.cfg.Server.Host, a.cfg.Server.Port)
@@ -54,6 +76,10 @@ func (a *App) Start() error {
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
 
+	// Stop background workers before closing the database they use
+	stopWorkers()
+	workers.Wait()
+
 	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Server.Timeout.Write)
 	defer cancel()
 
@@ -69,3 +95,47 @@ func (a *App) Start() error {
 
 	return nil
 }
+
+// runReservationSweeper periodically expires reservations whose hold time has passed
+func (a *App) runReservationSweeper(ctx context.Context) {
+	interval := a.cfg.Reservation.SweepInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := a.reservationService.ExpireStale(ctx); err != nil {
+				a.logger.Error("failed to sweep stale reservations", zap.Error(err))
+			}
+		}
+	}
+}
+
+// runPriceScheduler periodically applies scheduled price changes whose effective time has come
+func (a *App) runPriceScheduler(ctx context.Context) {
+	interval := a.cfg.Pricing.ScheduleInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := a.priceService.ApplyDue(ctx); err != nil {
+				a.logger.Error("failed to apply scheduled price changes", zap.Error(err))
+			}
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"Unnispick/internal/domain/delivery/http/handler"
 	"Unnispick/internal/domain/delivery/http/middleware"
 	"Unnispick/internal/domain/delivery/router"
+	"Unnispick/internal/domain/entity"
 	"Unnispick/internal/domain/repository"
 	"Unnispick/internal/domain/service"
 	"Unnispick/internal/infra/metrics"
@@ -15,8 +16,12 @@ import (
 	"Unnispick/pkg/databases"
 	"Unnispick/pkg/databases/postgres"
 	"Unnispick/pkg/logger"
+	"Unnispick/pkg/media"
+	"Unnispick/pkg/media/local"
+	"Unnispick/pkg/media/s3"
 	"Unnispick/pkg/validator"
 	"context"
+	"fmt"
 	"github.com/google/wire"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -50,6 +55,59 @@ func provideDB(conn *postgres.Database) *gorm.DB {
 	return conn.DB()
 }
 
+func provideReservationOptions(cfg *config.Config) service.ReservationOptions {
+	return service.ReservationOptions{
+		DefaultTTL: cfg.Reservation.DefaultTTL,
+		MaxTTL:     cfg.Reservation.MaxTTL,
+	}
+}
+
+func provideSuggestionOptions(cfg *config.Config) service.SuggestionOptions {
+	return service.SuggestionOptions{
+		Threshold: cfg.Search.SuggestionThreshold,
+	}
+}
+
+func provideProductImageOptions(cfg *config.Config) service.ProductImageOptions {
+	return service.ProductImageOptions{
+		MaxUploadSize: cfg.Media.MaxUploadSize,
+	}
+}
+
+func provideProductImageHandlerOptions(cfg *config.Config) handler.ProductImageOptions {
+	return handler.ProductImageOptions{
+		MaxUploadSize: cfg.Media.MaxUploadSize,
+	}
+}
+
+func provideProductImportHandlerOptions(cfg *config.Config) handler.ProductImportOptions {
+	return handler.ProductImportOptions{
+		MaxFileSize: cfg.Import.MaxFileSize,
+	}
+}
+
+func provideMediaStorage(cfg *config.Config) (media.MediaStorage, error) {
+	switch cfg.Media.Driver {
+	case "s3":
+		return s3.NewStorage(s3.Options{
+			Endpoint:     cfg.Media.S3.Endpoint,
+			Region:       cfg.Media.S3.Region,
+			Bucket:       cfg.Media.S3.Bucket,
+			AccessKey:    cfg.Media.S3.AccessKey,
+			SecretKey:    cfg.Media.S3.SecretKey,
+			PublicURL:    cfg.Media.S3.PublicURL,
+			UsePathStyle: cfg.Media.S3.UsePathStyle,
+		})
+	case "", "local":
+		return local.NewStorage(local.Options{
+			Root:    cfg.Media.Local.Root,
+			BaseURL: cfg.Media.Local.BaseURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown media driver %q", cfg.Media.Driver)
+	}
+}
+
 func provideDatabaseOptions(cfg *config.Config) postgres.Options {
 	return postgres.Options{
 		Host:         cfg.Database.Host,
@@ -77,21 +135,70 @@ var infraSet = wire.NewSet(
 	tracing.NewTracer,
 	metrics.NewMetrics,
 	validator.NewValidator,
+	provideMediaStorage,
 )
 
 var repositorySet = wire.NewSet(
 	repository.NewBrandRepository,
 	repository.NewProductRepository,
+	repository.NewStockMovementRepository,
+	repository.NewReservationRepository,
+	repository.NewProductVariantRepository,
+	repository.NewCategoryRepository,
+	repository.NewTagRepository,
+	repository.NewIngredientRepository,
+	repository.NewProductImageRepository,
+	repository.NewExchangeRateRepository,
+	repository.NewPriceListRepository,
+	repository.NewProductPriceRepository,
+	repository.NewPromotionRepository,
+	repository.NewSlugRedirectRepository,
+	repository.NewAuditRepository,
+	repository.NewSuggestionRepository,
 )
 
 var serviceSet = wire.NewSet(
 	service.NewBrandService,
 	service.NewProductService,
+	service.NewReservationService,
+	service.NewProductVariantService,
+	service.NewCategoryService,
+	service.NewTagService,
+	service.NewIngredientService,
+	service.NewProductImageService,
+	provideProductImageOptions,
+	service.NewExchangeRateService,
+	service.NewPriceListService,
+	service.NewProductPriceService,
+	service.NewPromotionService,
+	service.NewPricingEvaluator,
+	service.NewAuditService,
+	service.NewProductImportService,
+	service.NewProductExportService,
+	service.NewSuggestionService,
+	provideSuggestionOptions,
+	provideReservationOptions,
 )
 
 var handlerSet = wire.NewSet(
 	handler.NewBrandHandler,
 	handler.NewProductHandler,
+	handler.NewReservationHandler,
+	handler.NewProductVariantHandler,
+	handler.NewCategoryHandler,
+	handler.NewTagHandler,
+	handler.NewIngredientHandler,
+	handler.NewProductImageHandler,
+	provideProductImageHandlerOptions,
+	handler.NewPriceListHandler,
+	handler.NewExchangeRateHandler,
+	handler.NewProductPriceHandler,
+	handler.NewPromotionHandler,
+	handler.NewAuditHandler,
+	handler.NewProductImportHandler,
+	provideProductImportHandlerOptions,
+	handler.NewProductExportHandler,
+	handler.NewSuggestionHandler,
 )
 
 var middlewareSet = wire.NewSet(
@@ -115,3 +222,33 @@ func InitializeApp() (*App, error) {
 	)
 	return nil, nil
 }
+
+// InitializeProductImporter builds the product import of the command line on an open database
+func InitializeProductImporter(db *gorm.DB, zapLogger *zap.Logger) entity.ProductImportService {
+	wire.Build(
+		tracing.NewTracer,
+		validator.NewValidator,
+		repositorySet,
+		serviceSet,
+	)
+	return nil
+}
+
+// InitializeProductExporter builds the product export of the command line on an open database
+func InitializeProductExporter(db *gorm.DB, zapLogger *zap.Logger) entity.ProductExportService {
+	wire.Build(
+		tracing.NewTracer,
+		repositorySet,
+		serviceSet,
+	)
+	return nil
+}
+
+// InitializeMediaStorage builds the storage of the product images configured for the API
+func InitializeMediaStorage() (media.MediaStorage, error) {
+	wire.Build(
+		configSet,
+		provideMediaStorage,
+	)
+	return nil, nil
+}
@@ -11,6 +11,7 @@ import (
 	"Unnispick/internal/domain/delivery/http/handler"
 	"Unnispick/internal/domain/delivery/http/middleware"
 	"Unnispick/internal/domain/delivery/router"
+	"Unnispick/internal/domain/entity"
 	"Unnispick/internal/domain/repository"
 	"Unnispick/internal/domain/service"
 	"Unnispick/internal/infra/metrics"
@@ -18,8 +19,12 @@ import (
 	"Unnispick/pkg/databases"
 	"Unnispick/pkg/databases/postgres"
 	"Unnispick/pkg/logger"
+	"Unnispick/pkg/media"
+	"Unnispick/pkg/media/local"
+	"Unnispick/pkg/media/s3"
 	"Unnispick/pkg/validator"
 	"context"
+	"fmt"
 	"github.com/google/wire"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -48,7 +53,10 @@ func InitializeApp() (*App, error) {
 	zapLogger := provideZapLogger(loggerLogger)
 	tracer := tracing.NewTracer(zapLogger)
 	brandRepository := repository.NewBrandRepository(db, tracer)
-	brandService := service.NewBrandService(brandRepository, zapLogger, tracer)
+	slugRedirectRepository := repository.NewSlugRedirectRepository(db, tracer)
+	auditRepository := repository.NewAuditRepository(db, tracer)
+	auditService := service.NewAuditService(auditRepository, zapLogger, tracer)
+	brandService := service.NewBrandService(brandRepository, slugRedirectRepository, auditService, zapLogger, tracer)
 	context := provideContext()
 	metricsMetrics, err := metrics.NewMetrics(context)
 	if err != nil {
@@ -57,14 +65,105 @@ func InitializeApp() (*App, error) {
 	validatorValidator := validator.NewValidator()
 	brandHandler := handler.NewBrandHandler(brandService, zapLogger, tracer, metricsMetrics, validatorValidator)
 	productRepository := repository.NewProductRepository(db, tracer)
-	productService := service.NewProductService(productRepository, brandRepository, zapLogger, tracer)
-	productHandler := handler.NewProductHandler(productService, zapLogger, tracer, metricsMetrics, validatorValidator)
+	categoryRepository := repository.NewCategoryRepository(db, tracer)
+	tagRepository := repository.NewTagRepository(db, tracer)
+	ingredientRepository := repository.NewIngredientRepository(db, tracer)
+	stockMovementRepository := repository.NewStockMovementRepository(db, tracer)
+	promotionRepository := repository.NewPromotionRepository(db, tracer)
+	pricingEvaluator := service.NewPricingEvaluator(promotionRepository, categoryRepository, zapLogger, tracer)
+	productService := service.NewProductService(productRepository, brandRepository, categoryRepository, tagRepository, ingredientRepository, stockMovementRepository, pricingEvaluator, slugRedirectRepository, auditService, zapLogger, tracer)
+	priceListRepository := repository.NewPriceListRepository(db, tracer)
+	exchangeRateRepository := repository.NewExchangeRateRepository(db, tracer)
+	priceListService := service.NewPriceListService(priceListRepository, productRepository, exchangeRateRepository, zapLogger, tracer)
+	productHandler := handler.NewProductHandler(productService, priceListService, zapLogger, tracer, metricsMetrics, validatorValidator)
+	reservationRepository := repository.NewReservationRepository(db, tracer)
+	reservationOptions := provideReservationOptions(configConfig)
+	reservationService := service.NewReservationService(reservationRepository, reservationOptions, zapLogger, tracer)
+	reservationHandler := handler.NewReservationHandler(reservationService, zapLogger, tracer, validatorValidator)
+	productVariantRepository := repository.NewProductVariantRepository(db, tracer)
+	productVariantService := service.NewProductVariantService(productVariantRepository, productRepository, zapLogger, tracer)
+	productVariantHandler := handler.NewProductVariantHandler(productVariantService, zapLogger, tracer, validatorValidator)
+	categoryService := service.NewCategoryService(categoryRepository, zapLogger, tracer)
+	categoryHandler := handler.NewCategoryHandler(categoryService, zapLogger, tracer, validatorValidator)
+	tagService := service.NewTagService(tagRepository, zapLogger, tracer)
+	tagHandler := handler.NewTagHandler(tagService, zapLogger, tracer, validatorValidator)
+	ingredientService := service.NewIngredientService(ingredientRepository, zapLogger, tracer)
+	ingredientHandler := handler.NewIngredientHandler(ingredientService, zapLogger, tracer, validatorValidator)
+	productImageRepository := repository.NewProductImageRepository(db, tracer)
+	mediaStorage, err := provideMediaStorage(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	productImageOptions := provideProductImageOptions(configConfig)
+	productImageService := service.NewProductImageService(productImageRepository, productRepository, mediaStorage, productImageOptions, zapLogger, tracer)
+	handlerProductImageOptions := provideProductImageHandlerOptions(configConfig)
+	productImageHandler := handler.NewProductImageHandler(productImageService, handlerProductImageOptions, zapLogger, tracer, validatorValidator)
+	priceListHandler := handler.NewPriceListHandler(priceListService, zapLogger, tracer, validatorValidator)
+	exchangeRateService := service.NewExchangeRateService(exchangeRateRepository, zapLogger, tracer)
+	exchangeRateHandler := handler.NewExchangeRateHandler(exchangeRateService, zapLogger, tracer, validatorValidator)
+	productPriceRepository := repository.NewProductPriceRepository(db, tracer)
+	productPriceService := service.NewProductPriceService(productPriceRepository, productRepository, auditService, zapLogger, tracer)
+	productPriceHandler := handler.NewProductPriceHandler(productPriceService, zapLogger, tracer, validatorValidator)
+	promotionService := service.NewPromotionService(promotionRepository, productRepository, brandRepository, categoryRepository, zapLogger, tracer)
+	promotionHandler := handler.NewPromotionHandler(promotionService, zapLogger, tracer, validatorValidator)
+	auditHandler := handler.NewAuditHandler(auditService, zapLogger, tracer)
+	productImportService := service.NewProductImportService(productService, productRepository, brandRepository, brandService, validatorValidator, zapLogger, tracer)
+	productImportOptions := provideProductImportHandlerOptions(configConfig)
+	productImportHandler := handler.NewProductImportHandler(productImportService, productImportOptions, zapLogger, tracer, metricsMetrics)
+	productExportService := service.NewProductExportService(productRepository, zapLogger, tracer)
+	productExportHandler := handler.NewProductExportHandler(productExportService, zapLogger, tracer)
+	suggestionRepository := repository.NewSuggestionRepository(db, tracer)
+	suggestionOptions := provideSuggestionOptions(configConfig)
+	suggestionService := service.NewSuggestionService(suggestionRepository, suggestionOptions, zapLogger, tracer)
+	suggestionHandler := handler.NewSuggestionHandler(suggestionService, zapLogger, tracer)
 	telemetryMiddleware := middleware.NewTelemetryMiddleware(zapLogger, tracer, metricsMetrics)
-	routerRouter := router.NewRouter(echo, brandHandler, productHandler, telemetryMiddleware)
-	app := NewApp(configConfig, echo, routerRouter, database, zapLogger)
+	routerRouter := router.NewRouter(echo, brandHandler, productHandler, reservationHandler, productVariantHandler, categoryHandler, tagHandler, ingredientHandler, productImageHandler, priceListHandler, exchangeRateHandler, productPriceHandler, promotionHandler, auditHandler, productImportHandler, productExportHandler, suggestionHandler, mediaStorage, telemetryMiddleware)
+	app := NewApp(configConfig, echo, routerRouter, database, zapLogger, reservationService, productPriceService)
 	return app, nil
 }
 
+// InitializeProductImporter builds the product import of the command line on an open database
+func InitializeProductImporter(db *gorm.DB, zapLogger *zap.Logger) entity.ProductImportService {
+	tracer := tracing.NewTracer(zapLogger)
+	productRepository := repository.NewProductRepository(db, tracer)
+	brandRepository := repository.NewBrandRepository(db, tracer)
+	categoryRepository := repository.NewCategoryRepository(db, tracer)
+	tagRepository := repository.NewTagRepository(db, tracer)
+	ingredientRepository := repository.NewIngredientRepository(db, tracer)
+	stockMovementRepository := repository.NewStockMovementRepository(db, tracer)
+	promotionRepository := repository.NewPromotionRepository(db, tracer)
+	pricingEvaluator := service.NewPricingEvaluator(promotionRepository, categoryRepository, zapLogger, tracer)
+	slugRedirectRepository := repository.NewSlugRedirectRepository(db, tracer)
+	auditRepository := repository.NewAuditRepository(db, tracer)
+	auditService := service.NewAuditService(auditRepository, zapLogger, tracer)
+	productService := service.NewProductService(productRepository, brandRepository, categoryRepository, tagRepository, ingredientRepository, stockMovementRepository, pricingEvaluator, slugRedirectRepository, auditService, zapLogger, tracer)
+	brandService := service.NewBrandService(brandRepository, slugRedirectRepository, auditService, zapLogger, tracer)
+	validatorValidator := validator.NewValidator()
+	productImportService := service.NewProductImportService(productService, productRepository, brandRepository, brandService, validatorValidator, zapLogger, tracer)
+	return productImportService
+}
+
+// InitializeProductExporter builds the product export of the command line on an open database
+func InitializeProductExporter(db *gorm.DB, zapLogger *zap.Logger) entity.ProductExportService {
+	tracer := tracing.NewTracer(zapLogger)
+	productRepository := repository.NewProductRepository(db, tracer)
+	productExportService := service.NewProductExportService(productRepository, zapLogger, tracer)
+	return productExportService
+}
+
+// InitializeMediaStorage builds the storage of the product images configured for the API
+func InitializeMediaStorage() (media.MediaStorage, error) {
+	configConfig, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	mediaStorage, err := provideMediaStorage(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	return mediaStorage, nil
+}
+
 // wire.go:
 
 var configSet = wire.NewSet(config.Load)
@@ -92,6 +191,59 @@ func provideDB(conn *postgres.Database) *gorm.DB {
 	return conn.DB()
 }
 
+func provideReservationOptions(cfg *config.Config) service.ReservationOptions {
+	return service.ReservationOptions{
+		DefaultTTL: cfg.Reservation.DefaultTTL,
+		MaxTTL:     cfg.Reservation.MaxTTL,
+	}
+}
+
+func provideSuggestionOptions(cfg *config.Config) service.SuggestionOptions {
+	return service.SuggestionOptions{
+		Threshold: cfg.Search.SuggestionThreshold,
+	}
+}
+
+func provideProductImageOptions(cfg *config.Config) service.ProductImageOptions {
+	return service.ProductImageOptions{
+		MaxUploadSize: cfg.Media.MaxUploadSize,
+	}
+}
+
+func provideProductImageHandlerOptions(cfg *config.Config) handler.ProductImageOptions {
+	return handler.ProductImageOptions{
+		MaxUploadSize: cfg.Media.MaxUploadSize,
+	}
+}
+
+func provideProductImportHandlerOptions(cfg *config.Config) handler.ProductImportOptions {
+	return handler.ProductImportOptions{
+		MaxFileSize: cfg.Import.MaxFileSize,
+	}
+}
+
+func provideMediaStorage(cfg *config.Config) (media.MediaStorage, error) {
+	switch cfg.Media.Driver {
+	case "s3":
+		return s3.NewStorage(s3.Options{
+			Endpoint:     cfg.Media.S3.Endpoint,
+			Region:       cfg.Media.S3.Region,
+			Bucket:       cfg.Media.S3.Bucket,
+			AccessKey:    cfg.Media.S3.AccessKey,
+			SecretKey:    cfg.Media.S3.SecretKey,
+			PublicURL:    cfg.Media.S3.PublicURL,
+			UsePathStyle: cfg.Media.S3.UsePathStyle,
+		})
+	case "", "local":
+		return local.NewStorage(local.Options{
+			Root:    cfg.Media.Local.Root,
+			BaseURL: cfg.Media.Local.BaseURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown media driver %q", cfg.Media.Driver)
+	}
+}
+
 func provideDatabaseOptions(cfg *config.Config) postgres.Options {
 	return postgres.Options{
 		Host:         cfg.Database.Host,
@@ -111,14 +263,16 @@ var infraSet = wire.NewSet(
 	provideEcho,
 	provideDB,
 	provideDatabaseOptions,
-	provideLoggerConfig, logger.NewLogger, provideZapLogger, postgres.NewConnection, wire.Bind(new(databases.DB), new(*postgres.Database)), tracing.NewTracer, metrics.NewMetrics, validator.NewValidator,
+	provideLoggerConfig, logger.NewLogger, provideZapLogger, postgres.NewConnection, wire.Bind(new(databases.DB), new(*postgres.Database)), tracing.NewTracer, metrics.NewMetrics, validator.NewValidator, provideMediaStorage,
 )
 
-var repositorySet = wire.NewSet(repository.NewBrandRepository, repository.NewProductRepository)
+var repositorySet = wire.NewSet(repository.NewBrandRepository, repository.NewProductRepository, repository.NewStockMovementRepository, repository.NewReservationRepository, repository.NewProductVariantRepository, repository.NewCategoryRepository, repository.NewTagRepository, repository.NewIngredientRepository, repository.NewProductImageRepository, repository.NewExchangeRateRepository, repository.NewPriceListRepository, repository.NewProductPriceRepository, repository.NewPromotionRepository, repository.NewSlugRedirectRepository, repository.NewAuditRepository, repository.NewSuggestionRepository)
 
-var serviceSet = wire.NewSet(service.NewBrandService, service.NewProductService)
+var serviceSet = wire.NewSet(service.NewBrandService, service.NewProductService, service.NewReservationService, service.NewProductVariantService, service.NewCategoryService, service.NewTagService, service.NewIngredientService, service.NewProductImageService, provideProductImageOptions, service.NewExchangeRateService, service.NewPriceListService, service.NewProductPriceService, service.NewPromotionService, service.NewPricingEvaluator, service.NewAuditService, service.NewProductImportService, service.NewProductExportService, service.NewSuggestionService, provideSuggestionOptions,
+	provideReservationOptions,
+)
 
-var handlerSet = wire.NewSet(handler.NewBrandHandler, handler.NewProductHandler)
+var handlerSet = wire.NewSet(handler.NewBrandHandler, handler.NewProductHandler, handler.NewReservationHandler, handler.NewProductVariantHandler, handler.NewCategoryHandler, handler.NewTagHandler, handler.NewIngredientHandler, handler.NewProductImageHandler, provideProductImageHandlerOptions, handler.NewPriceListHandler, handler.NewExchangeRateHandler, handler.NewProductPriceHandler, handler.NewPromotionHandler, handler.NewAuditHandler, handler.NewProductImportHandler, provideProductImportHandlerOptions, handler.NewProductExportHandler, handler.NewSuggestionHandler)
 
 var middlewareSet = wire.NewSet(middleware.NewTelemetryMiddleware)
 
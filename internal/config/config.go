@@ -7,10 +7,15 @@ import (
 )
 
 type Config struct {
-	Server    ServerConfig    `mapstructure:"server"`
-	Database  DatabaseConfig  `mapstructure:"database"`
-	Telemetry TelemetryConfig `mapstructure:"telemetry"`
-	Logger    LoggerConfig    `mapstructure:"logger"`
+	Server      ServerConfig      `mapstructure:"server"`
+	Database    DatabaseConfig    `mapstructure:"database"`
+	Telemetry   TelemetryConfig   `mapstructure:"telemetry"`
+	Logger      LoggerConfig      `mapstructure:"logger"`
+	Reservation ReservationConfig `mapstructure:"reservation"`
+	Media       MediaConfig       `mapstructure:"media"`
+	Pricing     PricingConfig     `mapstructure:"pricing"`
+	Search      SearchConfig      `mapstructure:"search"`
+	Import      ImportConfig      `mapstructure:"import"`
 }
 
 type ServerConfig struct {
@@ -41,6 +46,46 @@ type PoolConfig struct {
 	MaxLifetime time.Duration `mapstructure:"max_lifetime"`
 }
 
+type ReservationConfig struct {
+	DefaultTTL    time.Duration `mapstructure:"default_ttl"`
+	MaxTTL        time.Duration `mapstructure:"max_ttl"`
+	SweepInterval time.Duration `mapstructure:"sweep_interval"`
+}
+
+type PricingConfig struct {
+	ScheduleInterval time.Duration `mapstructure:"schedule_interval"`
+}
+
+type SearchConfig struct {
+	SuggestionThreshold float64 `mapstructure:"suggestion_threshold"`
+}
+
+type ImportConfig struct {
+	MaxFileSize int64 `mapstructure:"max_file_size"`
+}
+
+type MediaConfig struct {
+	Driver        string           `mapstructure:"driver"`
+	MaxUploadSize int64            `mapstructure:"max_upload_size"`
+	Local         LocalMediaConfig `mapstructure:"local"`
+	S3            S3MediaConfig    `mapstructure:"s3"`
+}
+
+type LocalMediaConfig struct {
+	Root    string `mapstructure:"root"`
+	BaseURL string `mapstructure:"base_url"`
+}
+
+type S3MediaConfig struct {
+	Endpoint     string `mapstructure:"endpoint"`
+	Region       string `mapstructure:"region"`
+	Bucket       string `mapstructure:"bucket"`
+	AccessKey    string `mapstructure:"access_key"`
+	SecretKey    string `mapstructure:"secret_key"`
+	PublicURL    string `mapstructure:"public_url"`
+	UsePathStyle bool   `mapstructure:"use_path_style"`
+}
+
 type LoggerConfig struct {
 	Level       string `mapstructure:"level"`
 	Environment string `mapstructure:"environment"`
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/utils/response_formatter"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type AuditHandler struct {
+	service entity.AuditService
+	logger  *zap.Logger
+	tracer  *tracing.Tracer
+}
+
+func NewAuditHandler(
+	service entity.AuditService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+		logger:  logger,
+		tracer:  tracer,
+	}
+}
+
+// GetAll
+// @Summary Get the audit log
+// @Description Get the paginated history of brand and product changes, newest first
+// @Tags audit
+// @Accept json
+// @Produce json
+// @Param entity query string false "Entity type (brand, product)"
+// @Param entity_id query string false "Entity ID"
+// @Param actor query string false "Who made the change"
+// @Param action query string false "Action such as create, update or delete"
+// @Param from query string false "Changes at or after this RFC 3339 time"
+// @Param to query string false "Changes at or before this RFC 3339 time"
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 10)"
+// @Success 200 {object} response_formatter.Response{data=[]entity.AuditLogResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /audit [get]
+func (h *AuditHandler) GetAll(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.audit.GetAll")
+	defer span.End()
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+	page, perPage = response_formatter.ValidatePagination(page, perPage)
+
+	filter := entity.AuditFilterRequest{
+		EntityType: c.QueryParam("entity"),
+		Actor:      c.QueryParam("actor"),
+		Action:     c.QueryParam("action"),
+		Page:       page,
+		PerPage:    perPage,
+	}
+
+	if filter.EntityType != "" && filter.EntityType != entity.AuditEntityBrand && filter.EntityType != entity.AuditEntityProduct {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid entity",
+			[]string{"entity must be one of brand, product"},
+		))
+	}
+
+	if entityID := c.QueryParam("entity_id"); entityID != "" {
+		id, err := uuid.Parse(entityID)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, response_formatter.Error(
+				http.StatusBadRequest,
+				"Invalid entity ID",
+				[]string{err.Error()},
+			))
+		}
+		filter.EntityID = id
+	}
+
+	var err error
+	if filter.From, err = parseTimeQuery(c, "from"); err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid from time",
+			[]string{err.Error()},
+		))
+	}
+	if filter.To, err = parseTimeQuery(c, "to"); err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid to time",
+			[]string{err.Error()},
+		))
+	}
+
+	logs, total, err := h.service.GetAll(ctx, filter)
+	if err != nil {
+		h.logger.Error("failed to get audit logs", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to get audit logs",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.WithPagination(
+		logs,
+		"Audit logs retrieved successfully",
+		page,
+		perPage,
+		total,
+	))
+}
+
+// parseTimeQuery reads an optional RFC 3339 query parameter, nil when it is not given
+func parseTimeQuery(c echo.Context, name string) (*time.Time, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 time such as 2024-01-02T15:04:05Z", name)
+	}
+	return &t, nil
+}
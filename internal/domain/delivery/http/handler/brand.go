@@ -6,6 +6,7 @@ import (
 	"Unnispick/internal/infra/tracing"
 	"Unnispick/pkg/validator"
 	"Unnispick/utils/response_formatter"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -78,8 +79,12 @@ func (h *BrandHandler) Create(c echo.Context) error {
 	brand, err := h.service.Create(ctx, req)
 	if err != nil {
 		h.logger.Error("failed to create brand", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
-			http.StatusInternalServerError,
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, entity.ErrBrandSlugTaken) {
+			statusCode = http.StatusConflict
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
 			"Failed to create brand",
 			[]string{err.Error()},
 		))
@@ -97,7 +102,8 @@ func (h *BrandHandler) Create(c echo.Context) error {
 // @Produce json
 // @Param page query int false "Page number (default: 1)"
 // @Param per_page query int false "Items per page (default: 10)"
-// @Param search query string false "Search term for brand name"
+// @Param search query string false "Full text search over the brand name and description, the last word matches as a prefix"
+// @Param country query string false "ISO 3166-1 alpha-2 country of origin"
 // @Success 200 {object} response_formatter.Response{data=[]entity.BrandResponse}
 // @Failure 400 {object} response_formatter.Response
 // @Failure 500 {object} response_formatter.Response
@@ -109,10 +115,22 @@ func (h *BrandHandler) GetAll(c echo.Context) error {
 	page, _ := strconv.Atoi(c.QueryParam("page"))
 	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
 	search := c.QueryParam("search")
+	country := c.QueryParam("country")
+
+	if country != "" {
+		if err := h.validate.ValidateVar(ctx, country, "country"); err != nil {
+			return c.JSON(http.StatusBadRequest, response_formatter.Error(
+				http.StatusBadRequest,
+				"Invalid country",
+				[]string{"country must be an ISO 3166-1 alpha-2 code"},
+			))
+		}
+	}
 
 	page, perPage = response_formatter.ValidatePagination(page, perPage)
 	filter := entity.BrandFilterRequest{
 		Search:  search,
+		Country: country,
 		Page:    page,
 		PerPage: perPage,
 	}
@@ -175,20 +193,63 @@ func (h *BrandHandler) GetByID(c echo.Context) error {
 		))
 	}
 
+	setETag(c, brand.Version)
+	return c.JSON(http.StatusOK, response_formatter.Success(brand, "Brand retrieved successfully"))
+}
+
+// GetBySlug
+// @Summary Get a brand by slug
+// @Description Get a brand by its URL slug, an old slug answers 301 with the current one
+// @Tags brands
+// @Accept json
+// @Produce json
+// @Param slug path string true "Brand slug"
+// @Success 200 {object} response_formatter.Response{data=entity.BrandResponse}
+// @Success 301 {object} response_formatter.Response{data=entity.SlugRedirectResponse}
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /brands/by-slug/{slug} [get]
+func (h *BrandHandler) GetBySlug(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.brand.GetBySlug")
+	defer span.End()
+
+	brand, err := h.service.GetBySlug(ctx, c.Param("slug"))
+	if err != nil {
+		var moved *entity.SlugMovedError
+		if errors.As(err, &moved) {
+			return slugMoved(c, moved, "Brand slug has moved")
+		}
+
+		h.logger.Error("failed to get brand by slug", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "brand not found" {
+			statusCode = http.StatusNotFound
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to get brand",
+			[]string{err.Error()},
+		))
+	}
+
+	setETag(c, brand.Version)
 	return c.JSON(http.StatusOK, response_formatter.Success(brand, "Brand retrieved successfully"))
 }
 
 // Update
 // @Summary Update a brand
-// @Description Update a brand's information by its ID
+// @Description Update a brand's information by its ID, If-Match must carry the ETag the brand was read with
 // @Tags brands
 // @Accept json
 // @Produce json
 // @Param id path string true "Brand ID"
+// @Param If-Match header string true "ETag of the brand being updated"
 // @Param brand body entity.UpdateBrandRequest true "Brand update request"
 // @Success 200 {object} response_formatter.Response{data=entity.BrandResponse}
 // @Failure 400 {object} response_formatter.Response
 // @Failure 404 {object} response_formatter.Response
+// @Failure 412 {object} response_formatter.Response
+// @Failure 428 {object} response_formatter.Response
 // @Failure 500 {object} response_formatter.Response
 // @Router /brands/{id} [put]
 func (h *BrandHandler) Update(c echo.Context) error {
@@ -204,6 +265,11 @@ func (h *BrandHandler) Update(c echo.Context) error {
 		))
 	}
 
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return preconditionFailed(c, err)
+	}
+
 	var req entity.UpdateBrandRequest
 	if err := c.Bind(&req); err != nil {
 		h.logger.Error("failed to bind request", zap.Error(err))
@@ -227,13 +293,95 @@ func (h *BrandHandler) Update(c echo.Context) error {
 		))
 	}
 
-	brand, err := h.service.Update(ctx, id, req)
+	brand, err := h.service.Update(ctx, id, version, req)
 	if err != nil {
 		h.logger.Error("failed to update brand", zap.Error(err))
-		statusCode := http.StatusInternalServerError
-		if err.Error() == "brand not found" {
-			statusCode = http.StatusNotFound
+		statusCode := brandUpdateStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to update brand",
+			[]string{err.Error()},
+		))
+	}
+
+	setETag(c, brand.Version)
+	return c.JSON(http.StatusOK, response_formatter.Success(brand, "Brand updated successfully"))
+}
+
+// Patch
+// @Summary Partially update a brand
+// @Description Apply a JSON merge patch (RFC 7396) to a brand, only the fields in the patch are validated and written and null removes a field
+// @Tags brands
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "Brand ID"
+// @Param If-Match header string true "ETag of the brand being updated"
+// @Param brand body entity.UpdateBrandRequest true "Fields to change"
+// @Success 200 {object} response_formatter.Response{data=entity.BrandResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 409 {object} response_formatter.Response
+// @Failure 412 {object} response_formatter.Response
+// @Failure 415 {object} response_formatter.Response
+// @Failure 428 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /brands/{id} [patch]
+func (h *BrandHandler) Patch(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.brand.Patch")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid brand ID",
+			[]string{err.Error()},
+		))
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return preconditionFailed(c, err)
+	}
+
+	patch, err := readMergePatch(c)
+	if err != nil {
+		return invalidMergePatch(c, err)
+	}
+
+	current, err := h.service.GetByID(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to get brand", zap.Error(err))
+		statusCode := brandUpdateStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to update brand",
+			[]string{err.Error()},
+		))
+	}
+
+	req := current.ToUpdateRequest()
+	if err := patch.Apply(&req); err != nil {
+		return invalidMergePatch(c, err)
+	}
+
+	if err := h.validate.ValidateFields(ctx, req, patch.Fields()...); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
 		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	brand, err := h.service.Update(ctx, id, version, req)
+	if err != nil {
+		h.logger.Error("failed to patch brand", zap.Error(err))
+		statusCode := brandUpdateStatusCode(err)
 		return c.JSON(statusCode, response_formatter.Error(
 			statusCode,
 			"Failed to update brand",
@@ -241,19 +389,23 @@ func (h *BrandHandler) Update(c echo.Context) error {
 		))
 	}
 
+	setETag(c, brand.Version)
 	return c.JSON(http.StatusOK, response_formatter.Success(brand, "Brand updated successfully"))
 }
 
 // Delete
 // @Summary Delete a brand
-// @Description Delete a brand by its ID
+// @Description Move a brand to the trash, it can be restored until it is purged
 // @Tags brands
 // @Accept json
 // @Produce json
 // @Param id path string true "Brand ID"
+// @Param If-Match header string true "ETag of the brand being deleted"
 // @Success 200 {object} response_formatter.Response
 // @Failure 400 {object} response_formatter.Response
 // @Failure 404 {object} response_formatter.Response
+// @Failure 412 {object} response_formatter.Response
+// @Failure 428 {object} response_formatter.Response
 // @Failure 500 {object} response_formatter.Response
 // @Router /brands/{id} [delete]
 func (h *BrandHandler) Delete(c echo.Context) error {
@@ -269,13 +421,20 @@ func (h *BrandHandler) Delete(c echo.Context) error {
 		))
 	}
 
-	if err := h.service.Delete(ctx, id); err != nil {
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return preconditionFailed(c, err)
+	}
+
+	if err := h.service.Delete(ctx, id, version); err != nil {
 		h.logger.Error("failed to delete brand", zap.Error(err))
 		statusCode := http.StatusInternalServerError
 		if err.Error() == "brand not found" {
 			statusCode = http.StatusNotFound
 		} else if err.Error() == "cannot delete brand: still has associated products" {
 			statusCode = http.StatusBadRequest
+		} else if errors.Is(err, entity.ErrVersionMismatch) {
+			statusCode = http.StatusPreconditionFailed
 		}
 		return c.JSON(statusCode, response_formatter.Error(
 			statusCode,
@@ -287,3 +446,101 @@ func (h *BrandHandler) Delete(c echo.Context) error {
 	h.metrics.RecordBrandDeleted(ctx)
 	return c.JSON(http.StatusOK, response_formatter.Success(nil, "Brand deleted successfully"))
 }
+
+// GetTrash
+// @Summary List trashed brands
+// @Description List soft deleted brands, most recently deleted first, until they are purged
+// @Tags brands
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 10)"
+// @Param search query string false "Search term for brand name"
+// @Success 200 {object} response_formatter.Response{data=[]entity.BrandResponse}
+// @Failure 500 {object} response_formatter.Response
+// @Router /brands/trash [get]
+func (h *BrandHandler) GetTrash(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.brand.GetTrash")
+	defer span.End()
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+	page, perPage = response_formatter.ValidatePagination(page, perPage)
+
+	filter := entity.TrashFilterRequest{
+		Search:  c.QueryParam("search"),
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	brands, total, err := h.service.GetTrash(ctx, filter)
+	if err != nil {
+		h.logger.Error("failed to get trashed brands", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to get trashed brands",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.WithPagination(
+		brands,
+		"Trashed brands retrieved successfully",
+		page,
+		perPage,
+		total,
+	))
+}
+
+// Restore
+// @Summary Restore a trashed brand
+// @Description Bring a soft deleted brand back from the trash
+// @Tags brands
+// @Accept json
+// @Produce json
+// @Param id path string true "Brand ID"
+// @Success 200 {object} response_formatter.Response{data=entity.BrandResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 409 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /brands/{id}/restore [post]
+func (h *BrandHandler) Restore(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.brand.Restore")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid brand ID",
+			[]string{err.Error()},
+		))
+	}
+
+	brand, err := h.service.Restore(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to restore brand", zap.Error(err))
+		statusCode := restoreStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to restore brand",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(brand, "Brand restored successfully"))
+}
+
+func brandUpdateStatusCode(err error) int {
+	switch {
+	case err.Error() == "brand not found":
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrBrandSlugTaken):
+		return http.StatusConflict
+	case errors.Is(err, entity.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/validator"
+	"Unnispick/utils/response_formatter"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+type CategoryHandler struct {
+	service  entity.CategoryService
+	logger   *zap.Logger
+	tracer   *tracing.Tracer
+	validate *validator.Validator
+}
+
+func NewCategoryHandler(
+	service entity.CategoryService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+	validate *validator.Validator,
+) *CategoryHandler {
+	return &CategoryHandler{
+		service:  service,
+		logger:   logger,
+		tracer:   tracer,
+		validate: validate,
+	}
+}
+
+// Create
+// @Summary Create a new category
+// @Description Create a new category, optionally nested under a parent category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param category body entity.CreateCategoryRequest true "Category creation request"
+// @Success 201 {object} response_formatter.Response{data=entity.CategoryResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /categories [post]
+func (h *CategoryHandler) Create(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.category.Create")
+	defer span.End()
+
+	var req entity.CreateCategoryRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	category, err := h.service.Create(ctx, req)
+	if err != nil {
+		h.logger.Error("failed to create category", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to create category",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusCreated, response_formatter.Created(category, "Category created successfully"))
+}
+
+// GetAll
+// @Summary Get all categories with pagination
+// @Description Get a list of all categories with pagination support
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 10)"
+// @Param search query string false "Search term for category name"
+// @Param parent_id query string false "Only return direct children of this category"
+// @Param root_only query bool false "Only return top level categories"
+// @Success 200 {object} response_formatter.Response{data=[]entity.CategoryResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /categories [get]
+func (h *CategoryHandler) GetAll(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.category.GetAll")
+	defer span.End()
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+	search := c.QueryParam("search")
+
+	page, perPage = response_formatter.ValidatePagination(page, perPage)
+	filter := entity.CategoryFilterRequest{
+		Search:  search,
+		Page:    page,
+		PerPage: perPage,
+	}
+	if parentID := c.QueryParam("parent_id"); parentID != "" {
+		if id, err := uuid.Parse(parentID); err == nil {
+			filter.ParentID = id
+		}
+	}
+	if rootOnly, err := strconv.ParseBool(c.QueryParam("root_only")); err == nil {
+		filter.RootOnly = rootOnly
+	}
+
+	categories, total, err := h.service.GetAll(ctx, filter)
+	if err != nil {
+		h.logger.Error("failed to get categories", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to get categories",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.WithPagination(
+		categories,
+		"Categorys retrieved successfully",
+		page,
+		perPage,
+		total,
+	))
+}
+
+// GetByID
+// @Summary Get a category by ID
+// @Description Get detailed information about a category by its ID
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Success 200 {object} response_formatter.Response{data=entity.CategoryResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /categories/{id} [get]
+func (h *CategoryHandler) GetByID(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.category.GetByID")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid category ID",
+			[]string{err.Error()},
+		))
+	}
+
+	category, err := h.service.GetByID(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to get category", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "category not found" {
+			statusCode = http.StatusNotFound
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to get category",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(category, "Category retrieved successfully"))
+}
+
+// Update
+// @Summary Update a category
+// @Description Update a category's name or move it under another parent
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param category body entity.UpdateCategoryRequest true "Category update request"
+// @Success 200 {object} response_formatter.Response{data=entity.CategoryResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /categories/{id} [put]
+func (h *CategoryHandler) Update(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.category.Update")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid category ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.UpdateCategoryRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	category, err := h.service.Update(ctx, id, req)
+	if err != nil {
+		h.logger.Error("failed to update category", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "category not found" {
+			statusCode = http.StatusNotFound
+		} else if errors.Is(err, entity.ErrCategoryCycle) {
+			statusCode = http.StatusBadRequest
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to update category",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(category, "Category updated successfully"))
+}
+
+// Delete
+// @Summary Delete a category
+// @Description Delete a category by its ID
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Success 200 {object} response_formatter.Response
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /categories/{id} [delete]
+func (h *CategoryHandler) Delete(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.category.Delete")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid category ID",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.service.Delete(ctx, id); err != nil {
+		h.logger.Error("failed to delete category", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "category not found" {
+			statusCode = http.StatusNotFound
+		} else if err.Error() == "cannot delete category: still has child categories" {
+			statusCode = http.StatusBadRequest
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to delete category",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(nil, "Category deleted successfully"))
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"Unnispick/utils/response_formatter"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	headerETag    = "ETag"
+	headerIfMatch = "If-Match"
+)
+
+var errIfMatchRequired = errors.New("If-Match header with the ETag of the record is required")
+
+// setETag exposes the version of a record as a strong entity tag such as "3"
+func setETag(c echo.Context, version int) {
+	c.Response().Header().Set(headerETag, strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersion reads the record version the client last saw from the If-Match header
+func ifMatchVersion(c echo.Context) (int, error) {
+	value := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if value == "" {
+		return 0, errIfMatchRequired
+	}
+
+	version, err := strconv.Atoi(strings.Trim(value, `"`))
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("If-Match %s does not match the current ETag", value)
+	}
+	return version, nil
+}
+
+// preconditionFailed answers a missing If-Match with 428 and one that cannot match with 412
+func preconditionFailed(c echo.Context, err error) error {
+	statusCode := http.StatusPreconditionFailed
+	if errors.Is(err, errIfMatchRequired) {
+		statusCode = http.StatusPreconditionRequired
+	}
+	return c.JSON(statusCode, response_formatter.Error(
+		statusCode,
+		"Precondition failed",
+		[]string{err.Error()},
+	))
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/validator"
+	"Unnispick/utils/response_formatter"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type ExchangeRateHandler struct {
+	service  entity.ExchangeRateService
+	logger   *zap.Logger
+	tracer   *tracing.Tracer
+	validate *validator.Validator
+}
+
+func NewExchangeRateHandler(
+	service entity.ExchangeRateService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+	validate *validator.Validator,
+) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		service:  service,
+		logger:   logger,
+		tracer:   tracer,
+		validate: validate,
+	}
+}
+
+// Upload
+// @Summary Upload exchange rates
+// @Description Create or replace the exchange rates from a base currency to one or more quote currencies
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Param rates body entity.UploadExchangeRatesRequest true "Exchange rates from the base currency"
+// @Success 200 {object} response_formatter.Response{data=[]entity.ExchangeRateResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /admin/exchange-rates [put]
+func (h *ExchangeRateHandler) Upload(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.exchangeRate.Upload")
+	defer span.End()
+
+	var req entity.UploadExchangeRatesRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	rates, err := h.service.Upload(ctx, req)
+	if err != nil {
+		h.logger.Error("failed to upload exchange rates", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, entity.ErrUnsupportedCurrency) || errors.Is(err, entity.ErrInvalidExchangeRate) {
+			statusCode = http.StatusBadRequest
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to upload exchange rates",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(rates, "Exchange rates uploaded successfully"))
+}
+
+// GetAll
+// @Summary List exchange rates
+// @Description Get all stored exchange rates
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Success 200 {object} response_formatter.Response{data=[]entity.ExchangeRateResponse}
+// @Failure 500 {object} response_formatter.Response
+// @Router /exchange-rates [get]
+func (h *ExchangeRateHandler) GetAll(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.exchangeRate.GetAll")
+	defer span.End()
+
+	rates, err := h.service.GetAll(ctx)
+	if err != nil {
+		h.logger.Error("failed to get exchange rates", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to get exchange rates",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(rates, "Exchange rates retrieved successfully"))
+}
@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/validator"
+	"Unnispick/utils/response_formatter"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+type IngredientHandler struct {
+	service  entity.IngredientService
+	logger   *zap.Logger
+	tracer   *tracing.Tracer
+	validate *validator.Validator
+}
+
+func NewIngredientHandler(
+	service entity.IngredientService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+	validate *validator.Validator,
+) *IngredientHandler {
+	return &IngredientHandler{
+		service:  service,
+		logger:   logger,
+		tracer:   tracer,
+		validate: validate,
+	}
+}
+
+// Create
+// @Summary Create a new ingredient
+// @Description Create a new ingredient identified by its INCI name
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param ingredient body entity.CreateIngredientRequest true "Ingredient creation request"
+// @Success 201 {object} response_formatter.Response{data=entity.IngredientResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /ingredients [post]
+func (h *IngredientHandler) Create(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.ingredient.Create")
+	defer span.End()
+
+	var req entity.CreateIngredientRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	ingredient, err := h.service.Create(ctx, req)
+	if err != nil {
+		h.logger.Error("failed to create ingredient", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to create ingredient",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusCreated, response_formatter.Created(ingredient, "Ingredient created successfully"))
+}
+
+// GetAll
+// @Summary Get all ingredients with pagination
+// @Description Get a list of all ingredients with pagination support
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 10)"
+// @Param search query string false "Search term for INCI or common name"
+// @Success 200 {object} response_formatter.Response{data=[]entity.IngredientResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /ingredients [get]
+func (h *IngredientHandler) GetAll(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.ingredient.GetAll")
+	defer span.End()
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+	search := c.QueryParam("search")
+
+	page, perPage = response_formatter.ValidatePagination(page, perPage)
+	filter := entity.IngredientFilterRequest{
+		Search:  search,
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	ingredients, total, err := h.service.GetAll(ctx, filter)
+	if err != nil {
+		h.logger.Error("failed to get ingredients", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to get ingredients",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.WithPagination(
+		ingredients,
+		"Ingredients retrieved successfully",
+		page,
+		perPage,
+		total,
+	))
+}
+
+// GetByID
+// @Summary Get an ingredient by ID
+// @Description Get detailed information about an ingredient by its ID
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param id path string true "Ingredient ID"
+// @Success 200 {object} response_formatter.Response{data=entity.IngredientResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /ingredients/{id} [get]
+func (h *IngredientHandler) GetByID(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.ingredient.GetByID")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid ingredient ID",
+			[]string{err.Error()},
+		))
+	}
+
+	ingredient, err := h.service.GetByID(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to get ingredient", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "ingredient not found" {
+			statusCode = http.StatusNotFound
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to get ingredient",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(ingredient, "Ingredient retrieved successfully"))
+}
+
+// Update
+// @Summary Update an ingredient
+// @Description Update an ingredient's INCI or common name
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param id path string true "Ingredient ID"
+// @Param ingredient body entity.UpdateIngredientRequest true "Ingredient update request"
+// @Success 200 {object} response_formatter.Response{data=entity.IngredientResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /ingredients/{id} [put]
+func (h *IngredientHandler) Update(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.ingredient.Update")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid ingredient ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.UpdateIngredientRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	ingredient, err := h.service.Update(ctx, id, req)
+	if err != nil {
+		h.logger.Error("failed to update ingredient", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "ingredient not found" {
+			statusCode = http.StatusNotFound
+		} else if errors.Is(err, entity.ErrEmptyIngredientName) {
+			statusCode = http.StatusBadRequest
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to update ingredient",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(ingredient, "Ingredient updated successfully"))
+}
+
+// Delete
+// @Summary Delete an ingredient
+// @Description Delete an ingredient by its ID
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param id path string true "Ingredient ID"
+// @Success 200 {object} response_formatter.Response
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /ingredients/{id} [delete]
+func (h *IngredientHandler) Delete(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.ingredient.Delete")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid ingredient ID",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.service.Delete(ctx, id); err != nil {
+		h.logger.Error("failed to delete ingredient", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "ingredient not found" {
+			statusCode = http.StatusNotFound
+		} else if err.Error() == "cannot delete ingredient: still used by products" {
+			statusCode = http.StatusBadRequest
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to delete ingredient",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(nil, "Ingredient deleted successfully"))
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"Unnispick/pkg/mergepatch"
+	"Unnispick/utils/response_formatter"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"io"
+	"mime"
+	"net/http"
+)
+
+var errNotMergePatch = fmt.Errorf("Content-Type must be %s", mergepatch.ContentType)
+
+// readMergePatch reads a JSON merge patch from the request body, other media types are rejected
+func readMergePatch(c echo.Context) (mergepatch.Patch, error) {
+	mediaType, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if err != nil || mediaType != mergepatch.ContentType {
+		return nil, errNotMergePatch
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	return mergepatch.Parse(body)
+}
+
+// invalidMergePatch answers 415 for a body that is not a merge patch and 400 for one that does
+// not apply
+func invalidMergePatch(c echo.Context, err error) error {
+	statusCode := http.StatusBadRequest
+	if errors.Is(err, errNotMergePatch) {
+		statusCode = http.StatusUnsupportedMediaType
+	}
+	return c.JSON(statusCode, response_formatter.Error(
+		statusCode,
+		"Invalid merge patch",
+		[]string{err.Error()},
+	))
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/validator"
+	"Unnispick/utils/response_formatter"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type PriceListHandler struct {
+	service  entity.PriceListService
+	logger   *zap.Logger
+	tracer   *tracing.Tracer
+	validate *validator.Validator
+}
+
+func NewPriceListHandler(
+	service entity.PriceListService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+	validate *validator.Validator,
+) *PriceListHandler {
+	return &PriceListHandler{
+		service:  service,
+		logger:   logger,
+		tracer:   tracer,
+		validate: validate,
+	}
+}
+
+// SetPrice
+// @Summary Set a product price in a currency
+// @Description Create or replace the explicit price of a product in the currency of the given price
+// @Tags price-lists
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param price body entity.SetPriceListItemRequest true "Price in the target currency"
+// @Success 200 {object} response_formatter.Response{data=entity.PriceListItemResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/prices [put]
+func (h *PriceListHandler) SetPrice(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.priceList.SetPrice")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.SetPriceListItemRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	item, err := h.service.SetPrice(ctx, productID, req)
+	if err != nil {
+		h.logger.Error("failed to set product price", zap.Error(err))
+		statusCode := priceListStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to set product price",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(item, "Product price set successfully"))
+}
+
+// GetAll
+// @Summary List product prices per currency
+// @Description Get the explicit prices of a product in every currency that has one
+// @Tags price-lists
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} response_formatter.Response{data=[]entity.PriceListItemResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/prices [get]
+func (h *PriceListHandler) GetAll(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.priceList.GetAll")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	items, err := h.service.GetAll(ctx, productID)
+	if err != nil {
+		h.logger.Error("failed to get product prices", zap.Error(err))
+		statusCode := priceListStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to get product prices",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(items, "Product prices retrieved successfully"))
+}
+
+// Delete
+// @Summary Remove a product price in a currency
+// @Description Remove the explicit price so the currency falls back to exchange rate conversion
+// @Tags price-lists
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param currency path string true "ISO 4217 currency code"
+// @Success 200 {object} response_formatter.Response
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/prices/{currency} [delete]
+func (h *PriceListHandler) Delete(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.priceList.Delete")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.service.Delete(ctx, productID, c.Param("currency")); err != nil {
+		h.logger.Error("failed to delete product price", zap.Error(err))
+		statusCode := priceListStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to delete product price",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(nil, "Product price deleted successfully"))
+}
+
+func priceListStatusCode(err error) int {
+	switch {
+	case errors.Is(err, entity.ErrPriceListItemNotFound), err.Error() == "product not found":
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrUnsupportedCurrency),
+		errors.Is(err, entity.ErrExchangeRateNotFound),
+		errors.Is(err, entity.ErrInvalidPrice):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"Unnispick/internal/infra/tracing"
 	"Unnispick/pkg/validator"
 	"Unnispick/utils/response_formatter"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -14,26 +15,29 @@ import (
 )
 
 type ProductHandler struct {
-	service  entity.ProductService
-	logger   *zap.Logger
-	tracer   *tracing.Tracer
-	metrics  *metrics.Metrics
-	validate *validator.Validator
+	service      entity.ProductService
+	priceService entity.PriceListService
+	logger       *zap.Logger
+	tracer       *tracing.Tracer
+	metrics      *metrics.Metrics
+	validate     *validator.Validator
 }
 
 func NewProductHandler(
 	service entity.ProductService,
+	priceService entity.PriceListService,
 	logger *zap.Logger,
 	tracer *tracing.Tracer,
 	metrics *metrics.Metrics,
 	validate *validator.Validator,
 ) *ProductHandler {
 	return &ProductHandler{
-		service:  service,
-		logger:   logger,
-		tracer:   tracer,
-		metrics:  metrics,
-		validate: validate,
+		service:      service,
+		priceService: priceService,
+		logger:       logger,
+		tracer:       tracer,
+		metrics:      metrics,
+		validate:     validate,
 	}
 }
 
@@ -78,8 +82,12 @@ func (h *ProductHandler) Create(c echo.Context) error {
 	product, err := h.service.Create(ctx, req)
 	if err != nil {
 		h.logger.Error("failed to create product", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
-			http.StatusInternalServerError,
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, entity.ErrProductSlugTaken) {
+			statusCode = http.StatusConflict
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
 			"Failed to create product",
 			[]string{err.Error()},
 		))
@@ -91,17 +99,27 @@ func (h *ProductHandler) Create(c echo.Context) error {
 
 // GetAll
 // @Summary Get all products with pagination and filters
-// @Description Get a list of all products with pagination and filtering support
+// @Description Get a list of all products with pagination and filtering support. With q the best matches come first and each product lists the fields that matched with the matched words marked.
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number (default: 1)"
 // @Param per_page query int false "Items per page (default: 10)"
+// @Param q query string false "Full text search over the product name and the brand name and description, the last word matches as a prefix. The matched fields are returned under highlights as escaped HTML with the matches wrapped in <mark>"
 // @Param brand_id query string false "Filter by brand ID"
-// @Param min_price query number false "Minimum price filter"
-// @Param max_price query number false "Maximum price filter"
-// @Param min_qty query int false "Minimum quantity filter"
-// @Param max_qty query int false "Maximum quantity filter"
+// @Param category_id query string false "Filter by category ID"
+// @Param include_descendants query bool false "Also match products in child categories of category_id"
+// @Param tags query string false "Comma separated tag names"
+// @Param tag_mode query string false "Match any (default) or all of the given tags" Enums(any, all)
+// @Param include_ingredients query string false "Comma separated ingredient names the product must all contain"
+// @Param exclude_ingredients query string false "Comma separated ingredient names the product must not contain"
+// @Param min_price query string false "Minimum price filter as a decimal string, e.g. 150000.00"
+// @Param max_price query string false "Maximum price filter as a decimal string, e.g. 500000.00"
+// @Param min_qty query int false "Minimum quantity filter, a bundle is matched on the complete bundles its component stock makes"
+// @Param max_qty query int false "Maximum quantity filter, a bundle is matched on the complete bundles its component stock makes"
+// @Param product_type query string false "Only simple products or only bundles" Enums(simple, bundle)
+// @Param status query string false "Lifecycle status to list (default: active), all lists every status" Enums(draft, active, discontinued, archived, all)
+// @Param currency query string false "Return prices in this ISO 4217 currency, e.g. KRW"
 // @Success 200 {object} response_formatter.Response{data=[]entity.ProductResponse}
 // @Failure 400 {object} response_formatter.Response
 // @Failure 500 {object} response_formatter.Response
@@ -114,37 +132,9 @@ func (h *ProductHandler) GetAll(c echo.Context) error {
 	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
 	page, perPage = response_formatter.ValidatePagination(page, perPage)
 
-	filter := entity.ProductFilterRequest{
-		Page:    perPage,
-		PerPage: response_formatter.CalculateOffset(page, perPage),
-	}
-
-	// Parse optional filters
-	if brandID := c.QueryParam("brand_id"); brandID != "" {
-		if id, err := uuid.Parse(brandID); err == nil {
-			filter.BrandID = id
-		}
-	}
-	if minPrice := c.QueryParam("min_price"); minPrice != "" {
-		if price, err := strconv.ParseFloat(minPrice, 64); err == nil {
-			filter.MinPrice = price
-		}
-	}
-	if maxPrice := c.QueryParam("max_price"); maxPrice != "" {
-		if price, err := strconv.ParseFloat(maxPrice, 64); err == nil {
-			filter.MaxPrice = price
-		}
-	}
-	if minQty := c.QueryParam("min_qty"); minQty != "" {
-		if qty, err := strconv.Atoi(minQty); err == nil {
-			filter.MinQty = qty
-		}
-	}
-	if maxQty := c.QueryParam("max_qty"); maxQty != "" {
-		if qty, err := strconv.Atoi(maxQty); err == nil {
-			filter.MaxQty = qty
-		}
-	}
+	filter := entity.ParseProductFilter(c.QueryParams())
+	filter.Page = perPage
+	filter.PerPage = response_formatter.CalculateOffset(page, perPage)
 
 	products, total, err := h.service.GetAll(ctx, filter)
 	if err != nil {
@@ -156,6 +146,22 @@ func (h *ProductHandler) GetAll(c echo.Context) error {
 		))
 	}
 
+	if currency := c.QueryParam("currency"); currency != "" {
+		responses := make([]*entity.ProductResponse, len(products))
+		for i := range products {
+			responses[i] = &products[i]
+		}
+		if err := h.priceService.ApplyCurrency(ctx, currency, responses...); err != nil {
+			h.logger.Error("failed to convert product prices", zap.Error(err))
+			statusCode := priceListStatusCode(err)
+			return c.JSON(statusCode, response_formatter.Error(
+				statusCode,
+				"Failed to get products",
+				[]string{err.Error()},
+			))
+		}
+	}
+
 	return c.JSON(http.StatusOK, response_formatter.WithPagination(
 		products,
 		"Products retrieved successfully",
@@ -172,6 +178,7 @@ func (h *ProductHandler) GetAll(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID"
+// @Param currency query string false "Return prices in this ISO 4217 currency, e.g. KRW"
 // @Success 200 {object} response_formatter.Response{data=entity.ProductResponse}
 // @Failure 400 {object} response_formatter.Response
 // @Failure 404 {object} response_formatter.Response
@@ -204,20 +211,89 @@ func (h *ProductHandler) GetByID(c echo.Context) error {
 		))
 	}
 
+	if currency := c.QueryParam("currency"); currency != "" {
+		if err := h.priceService.ApplyCurrency(ctx, currency, product); err != nil {
+			h.logger.Error("failed to convert product price", zap.Error(err))
+			statusCode := priceListStatusCode(err)
+			return c.JSON(statusCode, response_formatter.Error(
+				statusCode,
+				"Failed to get product",
+				[]string{err.Error()},
+			))
+		}
+	}
+
+	setETag(c, product.Version)
+	return c.JSON(http.StatusOK, response_formatter.Success(product, "Product retrieved successfully"))
+}
+
+// GetBySlug
+// @Summary Get a product by slug
+// @Description Get a product by its URL slug, an old slug answers 301 with the current one
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param slug path string true "Product slug"
+// @Param currency query string false "Return prices in this ISO 4217 currency, e.g. KRW"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductResponse}
+// @Success 301 {object} response_formatter.Response{data=entity.SlugRedirectResponse}
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/by-slug/{slug} [get]
+func (h *ProductHandler) GetBySlug(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.GetBySlug")
+	defer span.End()
+
+	product, err := h.service.GetBySlug(ctx, c.Param("slug"))
+	if err != nil {
+		var moved *entity.SlugMovedError
+		if errors.As(err, &moved) {
+			return slugMoved(c, moved, "Product slug has moved")
+		}
+
+		h.logger.Error("failed to get product by slug", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "product not found" {
+			statusCode = http.StatusNotFound
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to get product",
+			[]string{err.Error()},
+		))
+	}
+
+	if currency := c.QueryParam("currency"); currency != "" {
+		if err := h.priceService.ApplyCurrency(ctx, currency, product); err != nil {
+			h.logger.Error("failed to convert product price", zap.Error(err))
+			statusCode := priceListStatusCode(err)
+			return c.JSON(statusCode, response_formatter.Error(
+				statusCode,
+				"Failed to get product",
+				[]string{err.Error()},
+			))
+		}
+	}
+
+	setETag(c, product.Version)
 	return c.JSON(http.StatusOK, response_formatter.Success(product, "Product retrieved successfully"))
 }
 
 // Update
 // @Summary Update a product
-// @Description Update a product's information by its ID
+// @Description Update a product's information by its ID, If-Match must carry the ETag the product was read with
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID"
+// @Param If-Match header string true "ETag of the product being updated"
 // @Param product body entity.UpdateProductRequest true "Product update request"
 // @Success 200 {object} response_formatter.Response{data=entity.ProductResponse}
 // @Failure 400 {object} response_formatter.Response
 // @Failure 404 {object} response_formatter.Response
+// @Failure 409 {object} response_formatter.Response
+// @Failure 412 {object} response_formatter.Response
+// @Failure 428 {object} response_formatter.Response
 // @Failure 500 {object} response_formatter.Response
 // @Router /products/{id} [put]
 func (h *ProductHandler) Update(c echo.Context) error {
@@ -233,6 +309,11 @@ func (h *ProductHandler) Update(c echo.Context) error {
 		))
 	}
 
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return preconditionFailed(c, err)
+	}
+
 	var req entity.UpdateProductRequest
 	if err := c.Bind(&req); err != nil {
 		h.logger.Error("failed to bind request", zap.Error(err))
@@ -256,13 +337,110 @@ func (h *ProductHandler) Update(c echo.Context) error {
 		))
 	}
 
-	product, err := h.service.Update(ctx, id, req)
+	product, err := h.service.Update(ctx, id, version, req)
 	if err != nil {
 		h.logger.Error("failed to update product", zap.Error(err))
-		statusCode := http.StatusInternalServerError
-		if err.Error() == "product not found" {
-			statusCode = http.StatusNotFound
+		statusCode := productUpdateStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to update product",
+			[]string{err.Error()},
+		))
+	}
+
+	h.metrics.RecordProductUpdated(ctx)
+	setETag(c, product.Version)
+	return c.JSON(http.StatusOK, response_formatter.Success(product, "Product updated successfully"))
+}
+
+// Patch
+// @Summary Partially update a product
+// @Description Apply a JSON merge patch (RFC 7396) to a product, only the fields in the patch are validated and written and null removes a field
+// @Tags products
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param If-Match header string true "ETag of the product being updated"
+// @Param product body entity.UpdateProductRequest true "Fields to change"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 409 {object} response_formatter.Response
+// @Failure 412 {object} response_formatter.Response
+// @Failure 415 {object} response_formatter.Response
+// @Failure 428 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id} [patch]
+func (h *ProductHandler) Patch(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.Patch")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return preconditionFailed(c, err)
+	}
+
+	patch, err := readMergePatch(c)
+	if err != nil {
+		return invalidMergePatch(c, err)
+	}
+
+	current, err := h.service.GetByID(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to get product", zap.Error(err))
+		statusCode := productUpdateStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to update product",
+			[]string{err.Error()},
+		))
+	}
+
+	req := current.ToUpdateRequest()
+	if err := patch.Apply(&req); err != nil {
+		return invalidMergePatch(c, err)
+	}
+
+	// Lists the patch leaves out stay as they are, a list the patch removes is cleared
+	switch {
+	case !patch.Has("category_ids"):
+		req.CategoryIDs = nil
+	case req.CategoryIDs == nil:
+		req.CategoryIDs = []uuid.UUID{}
+	}
+	switch {
+	case !patch.Has("tags"):
+		req.Tags = nil
+	case req.Tags == nil:
+		req.Tags = []string{}
+	}
+
+	if err := h.validate.ValidateFields(ctx, req, patch.Fields()...); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
 		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	product, err := h.service.Update(ctx, id, version, req)
+	if err != nil {
+		h.logger.Error("failed to patch product", zap.Error(err))
+		statusCode := productUpdateStatusCode(err)
 		return c.JSON(statusCode, response_formatter.Error(
 			statusCode,
 			"Failed to update product",
@@ -271,19 +449,24 @@ func (h *ProductHandler) Update(c echo.Context) error {
 	}
 
 	h.metrics.RecordProductUpdated(ctx)
+	setETag(c, product.Version)
 	return c.JSON(http.StatusOK, response_formatter.Success(product, "Product updated successfully"))
 }
 
 // Delete
 // @Summary Delete a product
-// @Description Delete a product by its ID
+// @Description Move a product to the trash, it can be restored until it is purged
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID"
+// @Param If-Match header string true "ETag of the product being deleted"
 // @Success 200 {object} response_formatter.Response
 // @Failure 400 {object} response_formatter.Response
 // @Failure 404 {object} response_formatter.Response
+// @Failure 409 {object} response_formatter.Response
+// @Failure 412 {object} response_formatter.Response
+// @Failure 428 {object} response_formatter.Response
 // @Failure 500 {object} response_formatter.Response
 // @Router /products/{id} [delete]
 func (h *ProductHandler) Delete(c echo.Context) error {
@@ -299,11 +482,20 @@ func (h *ProductHandler) Delete(c echo.Context) error {
 		))
 	}
 
-	if err := h.service.Delete(ctx, id); err != nil {
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return preconditionFailed(c, err)
+	}
+
+	if err := h.service.Delete(ctx, id, version); err != nil {
 		h.logger.Error("failed to delete product", zap.Error(err))
 		statusCode := http.StatusInternalServerError
 		if err.Error() == "product not found" {
 			statusCode = http.StatusNotFound
+		} else if errors.Is(err, entity.ErrProductInBundle) {
+			statusCode = http.StatusConflict
+		} else if errors.Is(err, entity.ErrVersionMismatch) {
+			statusCode = http.StatusPreconditionFailed
 		}
 		return c.JSON(statusCode, response_formatter.Error(
 			statusCode,
@@ -315,3 +507,633 @@ func (h *ProductHandler) Delete(c echo.Context) error {
 	h.metrics.RecordProductDeleted(ctx)
 	return c.JSON(http.StatusOK, response_formatter.Success(nil, "Product deleted successfully"))
 }
+
+// IncreaseStock
+// @Summary Increase product stock
+// @Description Atomically increase the stock quantity of a product by the given amount
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param stock body entity.StockAdjustmentRequest true "Stock adjustment request"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/stock/increase [post]
+func (h *ProductHandler) IncreaseStock(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.IncreaseStock")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.StockAdjustmentRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	product, err := h.service.IncreaseStock(ctx, id, req)
+	if err != nil {
+		h.logger.Error("failed to increase product stock", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "product not found" {
+			statusCode = http.StatusNotFound
+		} else if errors.Is(err, entity.ErrInvalidAmount) || errors.Is(err, entity.ErrBundleStockDerived) {
+			statusCode = http.StatusBadRequest
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to increase product stock",
+			[]string{err.Error()},
+		))
+	}
+
+	h.metrics.RecordProductUpdated(ctx)
+	return c.JSON(http.StatusOK, response_formatter.Success(product, "Product stock increased successfully"))
+}
+
+// DecreaseStock
+// @Summary Decrease product stock
+// @Description Atomically decrease the stock quantity of a product by the given amount, selling a bundle decreases every component
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param stock body entity.StockAdjustmentRequest true "Stock adjustment request"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 409 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/stock/decrease [post]
+func (h *ProductHandler) DecreaseStock(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.DecreaseStock")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.StockAdjustmentRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	product, err := h.service.DecreaseStock(ctx, id, req)
+	if err != nil {
+		h.logger.Error("failed to decrease product stock", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "product not found" {
+			statusCode = http.StatusNotFound
+		} else if errors.Is(err, entity.ErrInvalidAmount) {
+			statusCode = http.StatusBadRequest
+		} else if errors.Is(err, entity.ErrInsufficientStock) {
+			statusCode = http.StatusConflict
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to decrease product stock",
+			[]string{err.Error()},
+		))
+	}
+
+	h.metrics.RecordProductUpdated(ctx)
+	return c.JSON(http.StatusOK, response_formatter.Success(product, "Product stock decreased successfully"))
+}
+
+// GetStockMovements
+// @Summary Get the stock movement history of a product
+// @Description Get the paginated ledger of every quantity change of a product, newest first
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 10)"
+// @Success 200 {object} response_formatter.Response{data=[]entity.StockMovementResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/stock-movements [get]
+func (h *ProductHandler) GetStockMovements(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.GetStockMovements")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+	page, perPage = response_formatter.ValidatePagination(page, perPage)
+
+	filter := entity.StockMovementFilterRequest{
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	movements, total, err := h.service.GetStockMovements(ctx, id, filter)
+	if err != nil {
+		h.logger.Error("failed to get stock movements", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "product not found" {
+			statusCode = http.StatusNotFound
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to get stock movements",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.WithPagination(
+		movements,
+		"Stock movements retrieved successfully",
+		page,
+		perPage,
+		total,
+	))
+}
+
+// SetIngredients
+// @Summary Set product ingredients
+// @Description Replace the ingredient list of a product, the list order is the INCI order
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param ingredients body entity.SetProductIngredientsRequest true "Ordered ingredient IDs"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/ingredients [put]
+func (h *ProductHandler) SetIngredients(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.SetIngredients")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.SetProductIngredientsRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	product, err := h.service.SetIngredients(ctx, id, req)
+	if err != nil {
+		h.logger.Error("failed to set product ingredients", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "product not found" {
+			statusCode = http.StatusNotFound
+		} else if errors.Is(err, entity.ErrDuplicateIngredient) || err.Error() == "one or more ingredients not found" {
+			statusCode = http.StatusBadRequest
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to set product ingredients",
+			[]string{err.Error()},
+		))
+	}
+
+	h.metrics.RecordProductUpdated(ctx)
+	return c.JSON(http.StatusOK, response_formatter.Success(product, "Product ingredients updated successfully"))
+}
+
+// CreateBundle
+// @Summary Create a bundle product
+// @Description Create a product sold as a set of other products, its availability is derived from the component stock
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param bundle body entity.CreateBundleRequest true "Bundle creation request"
+// @Success 201 {object} response_formatter.Response{data=entity.ProductResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/bundles [post]
+func (h *ProductHandler) CreateBundle(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.CreateBundle")
+	defer span.End()
+
+	var req entity.CreateBundleRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	product, err := h.service.CreateBundle(ctx, req)
+	if err != nil {
+		h.logger.Error("failed to create bundle", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, entity.ErrInvalidBundleComponent) || errors.Is(err, entity.ErrDuplicateBundleComponent) {
+			statusCode = http.StatusBadRequest
+		} else if errors.Is(err, entity.ErrProductSlugTaken) {
+			statusCode = http.StatusConflict
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to create bundle",
+			[]string{err.Error()},
+		))
+	}
+
+	h.metrics.RecordProductCreated(ctx)
+	return c.JSON(http.StatusCreated, response_formatter.Created(product, "Bundle created successfully"))
+}
+
+// SetBundleComponents
+// @Summary Set bundle components
+// @Description Replace the products and quantities a bundle is made of
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Bundle product ID"
+// @Param components body entity.SetBundleComponentsRequest true "Component products and quantities per bundle"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/components [put]
+func (h *ProductHandler) SetBundleComponents(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.SetBundleComponents")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.SetBundleComponentsRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	product, err := h.service.SetBundleComponents(ctx, id, req)
+	if err != nil {
+		h.logger.Error("failed to set bundle components", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "product not found" {
+			statusCode = http.StatusNotFound
+		} else if errors.Is(err, entity.ErrNotBundle) ||
+			errors.Is(err, entity.ErrInvalidBundleComponent) ||
+			errors.Is(err, entity.ErrDuplicateBundleComponent) {
+			statusCode = http.StatusBadRequest
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to set bundle components",
+			[]string{err.Error()},
+		))
+	}
+
+	h.metrics.RecordProductUpdated(ctx)
+	return c.JSON(http.StatusOK, response_formatter.Success(product, "Bundle components updated successfully"))
+}
+
+// Publish
+// @Summary Publish a product
+// @Description Make a draft or discontinued product active so it is listed again
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 409 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/publish [post]
+func (h *ProductHandler) Publish(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.Publish")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	product, err := h.service.Publish(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to publish product", zap.Error(err))
+		statusCode := productStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to publish product",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(product, "Product published successfully"))
+}
+
+// Discontinue
+// @Summary Discontinue a product
+// @Description Stop selling an active product while keeping it visible to admin views
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 409 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/discontinue [post]
+func (h *ProductHandler) Discontinue(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.Discontinue")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	product, err := h.service.Discontinue(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to discontinue product", zap.Error(err))
+		statusCode := productStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to discontinue product",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(product, "Product discontinued successfully"))
+}
+
+// Archive
+// @Summary Archive a product
+// @Description Retire a product for good, archived products cannot move to another status
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 409 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/archive [post]
+func (h *ProductHandler) Archive(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.Archive")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	product, err := h.service.Archive(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to archive product", zap.Error(err))
+		statusCode := productStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to archive product",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(product, "Product archived successfully"))
+}
+
+func productStatusCode(err error) int {
+	switch {
+	case err.Error() == "product not found":
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrInvalidStatusTransition):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GetTrash
+// @Summary List trashed products
+// @Description List soft deleted products, most recently deleted first, until they are purged
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 10)"
+// @Param search query string false "Search term for product name"
+// @Success 200 {object} response_formatter.Response{data=[]entity.ProductResponse}
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/trash [get]
+func (h *ProductHandler) GetTrash(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.GetTrash")
+	defer span.End()
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+	page, perPage = response_formatter.ValidatePagination(page, perPage)
+
+	filter := entity.TrashFilterRequest{
+		Search:  c.QueryParam("search"),
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	products, total, err := h.service.GetTrash(ctx, filter)
+	if err != nil {
+		h.logger.Error("failed to get trashed products", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to get trashed products",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.WithPagination(
+		products,
+		"Trashed products retrieved successfully",
+		page,
+		perPage,
+		total,
+	))
+}
+
+// Restore
+// @Summary Restore a trashed product
+// @Description Bring a soft deleted product back from the trash
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 409 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/restore [post]
+func (h *ProductHandler) Restore(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.Restore")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	product, err := h.service.Restore(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to restore product", zap.Error(err))
+		statusCode := restoreStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to restore product",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(product, "Product restored successfully"))
+}
+
+func productUpdateStatusCode(err error) int {
+	switch {
+	case err.Error() == "product not found":
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrCurrencyMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, entity.ErrProductSlugTaken),
+		errors.Is(err, entity.ErrInsufficientStock):
+		return http.StatusConflict
+	case errors.Is(err, entity.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/utils/response_formatter"
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+// BulkCreate
+// @Summary Create products in bulk
+// @Description Create up to 500 products in one transaction. Every item is validated on its own and the response lists the outcome of each one. With atomic=true nothing is written unless every item succeeds, otherwise the valid items are written.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param atomic query bool false "Write nothing unless every item succeeds (default: false)"
+// @Param products body entity.BulkCreateProductsRequest true "Products to create"
+// @Success 201 {object} response_formatter.Response{data=entity.BulkResponse}
+// @Success 207 {object} response_formatter.Response{data=entity.BulkResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 422 {object} response_formatter.Response{data=entity.BulkResponse}
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/bulk [post]
+func (h *ProductHandler) BulkCreate(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.BulkCreate")
+	defer span.End()
+
+	var req entity.BulkCreateProductsRequest
+	atomic, rejected, failure := h.bindBulk(ctx, c, &req, func() int { return len(req.Items) }, func(i int) interface{} {
+		return req.Items[i]
+	})
+	if failure != nil {
+		return c.JSON(failure.Code, failure)
+	}
+	req.Atomic = atomic
+
+	response, err := h.service.BulkCreate(ctx, req, rejected)
+	if err != nil {
+		h.logger.Error("failed to create products", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to create products",
+			[]string{err.Error()},
+		))
+	}
+
+	h.metrics.RecordProductsCreated(ctx, response.Succeeded)
+	return bulkResult(c, response, http.StatusCreated, "created")
+}
+
+// BulkUpdate
+// @Summary Update products in bulk
+// @Description Fully update up to 500 products in one transaction, each item carries the product ID and the version it was read at. The response lists the outcome of each item. With atomic=true nothing is written unless every item succeeds, otherwise the valid items are written.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param atomic query bool false "Write nothing unless every item succeeds (default: false)"
+// @Param products body entity.BulkUpdateProductsRequest true "Products to update"
+// @Success 200 {object} response_formatter.Response{data=entity.BulkResponse}
+// @Success 207 {object} response_formatter.Response{data=entity.BulkResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 422 {object} response_formatter.Response{data=entity.BulkResponse}
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/bulk [put]
+func (h *ProductHandler) BulkUpdate(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.BulkUpdate")
+	defer span.End()
+
+	var req entity.BulkUpdateProductsRequest
+	atomic, rejected, failure := h.bindBulk(ctx, c, &req, func() int { return len(req.Items) }, func(i int) interface{} {
+		return req.Items[i]
+	})
+	if failure != nil {
+		return c.JSON(failure.Code, failure)
+	}
+	req.Atomic = atomic
+
+	response, err := h.service.BulkUpdate(ctx, req, rejected)
+	if err != nil {
+		h.logger.Error("failed to update products", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to update products",
+			[]string{err.Error()},
+		))
+	}
+
+	h.metrics.RecordProductsUpdated(ctx, response.Succeeded)
+	return bulkResult(c, response, http.StatusOK, "updated")
+}
+
+// BulkDelete
+// @Summary Delete products in bulk
+// @Description Move up to 500 products to the trash in one transaction, each item carries the product ID and the version it was read at. The response lists the outcome of each item. With atomic=true nothing is deleted unless every item succeeds.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param atomic query bool false "Delete nothing unless every item succeeds (default: false)"
+// @Param products body entity.BulkDeleteProductsRequest true "Products to delete"
+// @Success 200 {object} response_formatter.Response{data=entity.BulkResponse}
+// @Success 207 {object} response_formatter.Response{data=entity.BulkResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 422 {object} response_formatter.Response{data=entity.BulkResponse}
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/bulk [delete]
+func (h *ProductHandler) BulkDelete(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.product.BulkDelete")
+	defer span.End()
+
+	var req entity.BulkDeleteProductsRequest
+	atomic, rejected, failure := h.bindBulk(ctx, c, &req, func() int { return len(req.Items) }, func(i int) interface{} {
+		return req.Items[i]
+	})
+	if failure != nil {
+		return c.JSON(failure.Code, failure)
+	}
+	req.Atomic = atomic
+
+	response, err := h.service.BulkDelete(ctx, req, rejected)
+	if err != nil {
+		h.logger.Error("failed to delete products", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to delete products",
+			[]string{err.Error()},
+		))
+	}
+
+	h.metrics.RecordProductsDeleted(ctx, response.Succeeded)
+	return bulkResult(c, response, http.StatusOK, "deleted")
+}
+
+// bindBulk reads the atomic flag and the items of a bulk request and validates every item on its
+// own, returning the messages of the rejected ones by index. A request unusable as a whole gets
+// the error response to answer with instead.
+func (h *ProductHandler) bindBulk(ctx context.Context, c echo.Context, req interface{}, count func() int, item func(i int) interface{}) (bool, map[int][]string, *response_formatter.Response) {
+	atomic, err := boolParam(c, "atomic")
+	if err != nil {
+		failure := response_formatter.Error(http.StatusBadRequest, "Invalid atomic parameter", []string{err.Error()})
+		return false, nil, &failure
+	}
+
+	if err := c.Bind(req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		failure := response_formatter.Error(http.StatusBadRequest, "Invalid request body", []string{err.Error()})
+		return false, nil, &failure
+	}
+
+	if count() == 0 || count() > entity.MaxBulkItems {
+		failure := response_formatter.Error(http.StatusBadRequest, "Validation failed", []string{
+			fmt.Sprintf("items must list between 1 and %d products", entity.MaxBulkItems),
+		})
+		return false, nil, &failure
+	}
+
+	rejected := map[int][]string{}
+	for i := 0; i < count(); i++ {
+		if err := h.validate.Validate(ctx, item(i)); err != nil {
+			var errorMessages []string
+			for _, ve := range h.validate.ExtractValidationErrors(err) {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s: %s", ve.Field, ve.Message))
+			}
+			rejected[i] = errorMessages
+		}
+	}
+
+	return atomic, rejected, nil
+}
+
+// boolParam reads a boolean query or form parameter that defaults to false. Unlike the lenient
+// list filters a value that is not a boolean is an error, these flags change what gets written.
+func boolParam(c echo.Context, name string) (bool, error) {
+	value := c.FormValue(name)
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// bulkResult answers with the success status when every item succeeded, 207 when only some did
+// and 422 when none was written
+func bulkResult(c echo.Context, response *entity.BulkResponse, success int, verb string) error {
+	statusCode, message := success, fmt.Sprintf("Products %s successfully", verb)
+	switch {
+	case response.Failed == 0:
+	case response.Atomic || response.Succeeded == 0:
+		statusCode, message = http.StatusUnprocessableEntity, fmt.Sprintf("No products were %s", verb)
+	default:
+		statusCode, message = http.StatusMultiStatus, fmt.Sprintf("Some products were not %s", verb)
+	}
+
+	return c.JSON(statusCode, response_formatter.WithStatus(statusCode, response, message))
+}
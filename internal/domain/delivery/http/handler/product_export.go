@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/utils/response_formatter"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+)
+
+// exportContentTypes are the response content types of the export formats
+var exportContentTypes = map[string]string{
+	entity.ExportFormatCSV:    "text/csv; charset=utf-8",
+	entity.ExportFormatNDJSON: "application/x-ndjson",
+}
+
+type ProductExportHandler struct {
+	service entity.ProductExportService
+	logger  *zap.Logger
+	tracer  *tracing.Tracer
+}
+
+func NewProductExportHandler(
+	service entity.ProductExportService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) *ProductExportHandler {
+	return &ProductExportHandler{
+		service: service,
+		logger:  logger,
+		tracer:  tracer,
+	}
+}
+
+// Export
+// @Summary Export the product catalog
+// @Description Stream every product matching the filters as CSV or newline delimited JSON, one product per row. The filters are the ones of the product list, pagination does not apply. The CSV columns carry the names the import reads, so an exported file can be imported again.
+// @Tags products
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "File format (default: csv)" Enums(csv, ndjson)
+// @Param q query string false "Full text search over the product name and the brand name and description, the last word matches as a prefix"
+// @Param brand_id query string false "Filter by brand ID"
+// @Param category_id query string false "Filter by category ID"
+// @Param include_descendants query bool false "Also match products in child categories of category_id"
+// @Param tags query string false "Comma separated tag names"
+// @Param tag_mode query string false "Match any (default) or all of the given tags" Enums(any, all)
+// @Param include_ingredients query string false "Comma separated ingredient names the product must all contain"
+// @Param exclude_ingredients query string false "Comma separated ingredient names the product must not contain"
+// @Param min_price query string false "Minimum price filter as a decimal string, e.g. 150000.00"
+// @Param max_price query string false "Maximum price filter as a decimal string, e.g. 500000.00"
+// @Param min_qty query int false "Minimum quantity filter, a bundle is matched on the complete bundles its component stock makes"
+// @Param max_qty query int false "Maximum quantity filter, a bundle is matched on the complete bundles its component stock makes"
+// @Param product_type query string false "Only simple products or only bundles" Enums(simple, bundle)
+// @Param status query string false "Lifecycle status to export (default: active), all exports every status" Enums(draft, active, discontinued, archived, all)
+// @Success 200 {file} file
+// @Failure 400 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/export [get]
+func (h *ProductExportHandler) Export(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productExport.Export")
+	defer span.End()
+
+	format := strings.ToLower(c.QueryParam("format"))
+	if format == "" {
+		format = entity.ExportFormatCSV
+	}
+	if !entity.IsExportFormat(format) {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid format parameter",
+			[]string{entity.ErrInvalidExportFormat.Error()},
+		))
+	}
+
+	filter := entity.ParseProductFilter(c.QueryParams())
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, exportContentTypes[format])
+	response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", "products."+format))
+
+	count, err := h.service.Export(ctx, filter, format, response)
+	if err != nil {
+		h.logger.Error("failed to export products", zap.Int("exported", count), zap.Error(err))
+
+		// Once rows went out the status is sent, the client sees the stream end early
+		if response.Committed {
+			return nil
+		}
+		response.Header().Del(echo.HeaderContentDisposition)
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to export products",
+			[]string{err.Error()},
+		))
+	}
+
+	return nil
+}
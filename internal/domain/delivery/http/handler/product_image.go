@@ -0,0 +1,333 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/validator"
+	"Unnispick/utils/response_formatter"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+// ProductImageOptions bounds the image uploads the handler reads
+type ProductImageOptions struct {
+	MaxUploadSize int64
+}
+
+type ProductImageHandler struct {
+	service  entity.ProductImageService
+	opts     ProductImageOptions
+	logger   *zap.Logger
+	tracer   *tracing.Tracer
+	validate *validator.Validator
+}
+
+func NewProductImageHandler(
+	service entity.ProductImageService,
+	opts ProductImageOptions,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+	validate *validator.Validator,
+) *ProductImageHandler {
+	return &ProductImageHandler{
+		service:  service,
+		opts:     opts,
+		logger:   logger,
+		tracer:   tracer,
+		validate: validate,
+	}
+}
+
+// Upload
+// @Summary Upload a product image
+// @Description Upload a jpeg, png, webp or gif image for a product, the first image of a product becomes its primary image
+// @Tags product-images
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param image formData file true "Image file"
+// @Param is_primary formData bool false "Make this the primary image"
+// @Success 201 {object} response_formatter.Response{data=entity.ProductImageResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 413 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/images [post]
+func (h *ProductImageHandler) Upload(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productImage.Upload")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	limitUpload(c, h.opts.MaxUploadSize)
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		if isBodyTooLarge(err) {
+			return c.JSON(http.StatusRequestEntityTooLarge, response_formatter.Error(
+				http.StatusRequestEntityTooLarge,
+				"Failed to upload product image",
+				[]string{entity.ErrImageTooLarge.Error()},
+			))
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{"image file is required"},
+		))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Error("failed to open uploaded image", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+	defer file.Close()
+
+	isPrimary, _ := strconv.ParseBool(c.FormValue("is_primary"))
+	req := entity.UploadProductImageRequest{
+		Filename:  fileHeader.Filename,
+		Size:      fileHeader.Size,
+		Body:      file,
+		IsPrimary: isPrimary,
+	}
+
+	image, err := h.service.Upload(ctx, productID, req)
+	if err != nil {
+		h.logger.Error("failed to upload product image", zap.Error(err))
+		statusCode := productImageStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to upload product image",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusCreated, response_formatter.Created(image, "Product image uploaded successfully"))
+}
+
+// GetAll
+// @Summary List product images
+// @Description Get all images of a product in display order
+// @Tags product-images
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} response_formatter.Response{data=[]entity.ProductImageResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/images [get]
+func (h *ProductImageHandler) GetAll(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productImage.GetAll")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	images, err := h.service.GetAll(ctx, productID)
+	if err != nil {
+		h.logger.Error("failed to get product images", zap.Error(err))
+		statusCode := productImageStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to get product images",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(images, "Product images retrieved successfully"))
+}
+
+// Reorder
+// @Summary Reorder product images
+// @Description Set the display order of a product's images, every image must be listed exactly once
+// @Tags product-images
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param order body entity.ReorderProductImagesRequest true "Image IDs in display order"
+// @Success 200 {object} response_formatter.Response{data=[]entity.ProductImageResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/images/order [put]
+func (h *ProductImageHandler) Reorder(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productImage.Reorder")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.ReorderProductImagesRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	images, err := h.service.Reorder(ctx, productID, req)
+	if err != nil {
+		h.logger.Error("failed to reorder product images", zap.Error(err))
+		statusCode := productImageStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to reorder product images",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(images, "Product images reordered successfully"))
+}
+
+// SetPrimary
+// @Summary Set the primary product image
+// @Description Make an image the primary image of its product
+// @Tags product-images
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param image_id path string true "Image ID"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductImageResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/images/{image_id}/primary [post]
+func (h *ProductImageHandler) SetPrimary(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productImage.SetPrimary")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	imageID, err := uuid.Parse(c.Param("image_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid image ID",
+			[]string{err.Error()},
+		))
+	}
+
+	image, err := h.service.SetPrimary(ctx, productID, imageID)
+	if err != nil {
+		h.logger.Error("failed to set primary product image", zap.Error(err))
+		statusCode := productImageStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to set primary product image",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(image, "Primary product image updated successfully"))
+}
+
+// Delete
+// @Summary Delete a product image
+// @Description Delete a product image and its stored file, the next image becomes primary if needed
+// @Tags product-images
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param image_id path string true "Image ID"
+// @Success 200 {object} response_formatter.Response
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/images/{image_id} [delete]
+func (h *ProductImageHandler) Delete(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productImage.Delete")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	imageID, err := uuid.Parse(c.Param("image_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid image ID",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.service.Delete(ctx, productID, imageID); err != nil {
+		h.logger.Error("failed to delete product image", zap.Error(err))
+		statusCode := productImageStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to delete product image",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(nil, "Product image deleted successfully"))
+}
+
+func productImageStatusCode(err error) int {
+	switch {
+	case errors.Is(err, entity.ErrProductImageNotFound), err.Error() == "product not found":
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrUnsupportedImageType), errors.Is(err, entity.ErrImageOrderMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, entity.ErrImageTooLarge):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
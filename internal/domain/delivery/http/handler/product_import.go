@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/metrics"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/utils/response_formatter"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// ProductImportOptions bounds the import files the handler reads
+type ProductImportOptions struct {
+	MaxFileSize int64
+}
+
+type ProductImportHandler struct {
+	service entity.ProductImportService
+	opts    ProductImportOptions
+	logger  *zap.Logger
+	tracer  *tracing.Tracer
+	metrics *metrics.Metrics
+}
+
+func NewProductImportHandler(
+	service entity.ProductImportService,
+	opts ProductImportOptions,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+	metrics *metrics.Metrics,
+) *ProductImportHandler {
+	return &ProductImportHandler{
+		service: service,
+		opts:    opts,
+		logger:  logger,
+		tracer:  tracer,
+		metrics: metrics,
+	}
+}
+
+// Import
+// @Summary Import products from a CSV file
+// @Description Create the products of a CSV file and update the ones whose name already exists. The header row names the columns product_name, price, quantity and brand_name and optionally slug, currency, category_ids and tags, list cells separate values with ";". The columns id, product_type, status, brand_id, version, created_at and updated_at of a catalog export are skipped. The report lists the outcome of every row. Files over the configured import.max_file_size are refused with 413.
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file"
+// @Param dry_run query bool false "Check every row without writing anything (default: false)"
+// @Param create_brands query bool false "Create brands that do not exist yet instead of rejecting their rows (default: false)"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductImportReport}
+// @Success 207 {object} response_formatter.Response{data=entity.ProductImportReport}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 413 {object} response_formatter.Response
+// @Failure 422 {object} response_formatter.Response{data=entity.ProductImportReport}
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/import [post]
+func (h *ProductImportHandler) Import(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productImport.Import")
+	defer span.End()
+
+	// The flags may come as form fields, reading them parses the body so it is bounded first
+	limitUpload(c, h.opts.MaxFileSize)
+
+	dryRun, err := boolParam(c, "dry_run")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid dry_run parameter",
+			[]string{err.Error()},
+		))
+	}
+	createBrands, err := boolParam(c, "create_brands")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid create_brands parameter",
+			[]string{err.Error()},
+		))
+	}
+	opts := entity.ProductImportOptions{DryRun: dryRun, CreateBrands: createBrands}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		if isBodyTooLarge(err) {
+			return importFileTooLarge(c)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{"CSV file is required"},
+		))
+	}
+	// The body limit leaves room for the multipart framing, the file itself is held to the size
+	if h.opts.MaxFileSize > 0 && fileHeader.Size > h.opts.MaxFileSize {
+		return importFileTooLarge(c)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Error("failed to open uploaded import file", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+	defer file.Close()
+
+	report, err := h.service.Import(ctx, file, opts)
+	if err != nil {
+		h.logger.Error("failed to import products", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, entity.ErrInvalidImportFile) {
+			statusCode = http.StatusBadRequest
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to import products",
+			[]string{err.Error()},
+		))
+	}
+
+	message := "Products imported successfully"
+	if report.DryRun {
+		message = "Import checked, nothing was written"
+	} else {
+		h.metrics.RecordProductsCreated(ctx, report.Created)
+		h.metrics.RecordProductsUpdated(ctx, report.Updated)
+	}
+
+	// A dry run reports the problems it found like a real import would fail on them
+	statusCode := http.StatusOK
+	switch {
+	case report.Failed == 0:
+	case report.Created+report.Updated == 0:
+		statusCode, message = http.StatusUnprocessableEntity, "No rows could be imported"
+	default:
+		statusCode, message = http.StatusMultiStatus, "Some rows could not be imported"
+	}
+
+	return c.JSON(statusCode, response_formatter.WithStatus(statusCode, report, message))
+}
+
+func importFileTooLarge(c echo.Context) error {
+	return c.JSON(http.StatusRequestEntityTooLarge, response_formatter.Error(
+		http.StatusRequestEntityTooLarge,
+		"Failed to import products",
+		[]string{entity.ErrImportFileTooLarge.Error()},
+	))
+}
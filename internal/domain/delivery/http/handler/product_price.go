@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/validator"
+	"Unnispick/utils/response_formatter"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+type ProductPriceHandler struct {
+	service  entity.ProductPriceService
+	logger   *zap.Logger
+	tracer   *tracing.Tracer
+	validate *validator.Validator
+}
+
+func NewProductPriceHandler(
+	service entity.ProductPriceService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+	validate *validator.Validator,
+) *ProductPriceHandler {
+	return &ProductPriceHandler{
+		service:  service,
+		logger:   logger,
+		tracer:   tracer,
+		validate: validate,
+	}
+}
+
+// GetHistory
+// @Summary Get the price history of a product
+// @Description Get the paginated list of price periods of a product, the current price first
+// @Tags product-prices
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 10)"
+// @Success 200 {object} response_formatter.Response{data=[]entity.ProductPriceResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/price-history [get]
+func (h *ProductPriceHandler) GetHistory(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productPrice.GetHistory")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+	page, perPage = response_formatter.ValidatePagination(page, perPage)
+
+	filter := entity.PriceHistoryFilterRequest{
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	prices, total, err := h.service.GetHistory(ctx, productID, filter)
+	if err != nil {
+		h.logger.Error("failed to get price history", zap.Error(err))
+		statusCode := productPriceStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to get price history",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.WithPagination(
+		prices,
+		"Price history retrieved successfully",
+		page,
+		perPage,
+		total,
+	))
+}
+
+// Schedule
+// @Summary Schedule a price change
+// @Description Schedule a new product price that is applied automatically once effective_from is reached
+// @Tags product-prices
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param schedule body entity.SchedulePriceChangeRequest true "Future price and the time it takes effect"
+// @Success 201 {object} response_formatter.Response{data=entity.ProductPriceResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/price-schedules [post]
+func (h *ProductPriceHandler) Schedule(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productPrice.Schedule")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.SchedulePriceChangeRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	price, err := h.service.Schedule(ctx, productID, req)
+	if err != nil {
+		h.logger.Error("failed to schedule price change", zap.Error(err))
+		statusCode := productPriceStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to schedule price change",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusCreated, response_formatter.Created(price, "Price change scheduled successfully"))
+}
+
+// GetScheduled
+// @Summary List scheduled price changes
+// @Description Get the price changes of a product that have not taken effect yet, soonest first
+// @Tags product-prices
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} response_formatter.Response{data=[]entity.ProductPriceResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/price-schedules [get]
+func (h *ProductPriceHandler) GetScheduled(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productPrice.GetScheduled")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	prices, err := h.service.GetScheduled(ctx, productID)
+	if err != nil {
+		h.logger.Error("failed to get scheduled price changes", zap.Error(err))
+		statusCode := productPriceStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to get scheduled price changes",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(prices, "Scheduled price changes retrieved successfully"))
+}
+
+// CancelScheduled
+// @Summary Cancel a scheduled price change
+// @Description Remove a price change that has not taken effect yet
+// @Tags product-prices
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param schedule_id path string true "Scheduled price change ID"
+// @Success 200 {object} response_formatter.Response
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/price-schedules/{schedule_id} [delete]
+func (h *ProductPriceHandler) CancelScheduled(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productPrice.CancelScheduled")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	id, err := uuid.Parse(c.Param("schedule_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid schedule ID",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.service.CancelScheduled(ctx, productID, id); err != nil {
+		h.logger.Error("failed to cancel scheduled price change", zap.Error(err))
+		statusCode := productPriceStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to cancel scheduled price change",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(nil, "Scheduled price change cancelled successfully"))
+}
+
+func productPriceStatusCode(err error) int {
+	switch {
+	case errors.Is(err, entity.ErrPriceScheduleNotFound), err.Error() == "product not found":
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrPriceScheduleInPast),
+		errors.Is(err, entity.ErrCurrencyMismatch):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,317 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/validator"
+	"Unnispick/utils/response_formatter"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type ProductVariantHandler struct {
+	service  entity.ProductVariantService
+	logger   *zap.Logger
+	tracer   *tracing.Tracer
+	validate *validator.Validator
+}
+
+func NewProductVariantHandler(
+	service entity.ProductVariantService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+	validate *validator.Validator,
+) *ProductVariantHandler {
+	return &ProductVariantHandler{
+		service:  service,
+		logger:   logger,
+		tracer:   tracer,
+		validate: validate,
+	}
+}
+
+// Create
+// @Summary Create a product variant
+// @Description Add a shade, size or volume variant with its own SKU, price and stock to a product
+// @Tags product-variants
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param variant body entity.CreateProductVariantRequest true "Product variant creation request"
+// @Success 201 {object} response_formatter.Response{data=entity.ProductVariantResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/variants [post]
+func (h *ProductVariantHandler) Create(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productVariant.Create")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.CreateProductVariantRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	variant, err := h.service.Create(ctx, productID, req)
+	if err != nil {
+		h.logger.Error("failed to create product variant", zap.Error(err))
+		statusCode := variantStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to create product variant",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusCreated, response_formatter.Created(variant, "Product variant created successfully"))
+}
+
+// GetAll
+// @Summary Get all variants of a product
+// @Description Get every variant of a product in the order they were added
+// @Tags product-variants
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} response_formatter.Response{data=[]entity.ProductVariantResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/variants [get]
+func (h *ProductVariantHandler) GetAll(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productVariant.GetAll")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	variants, err := h.service.GetAll(ctx, productID)
+	if err != nil {
+		h.logger.Error("failed to get product variants", zap.Error(err))
+		statusCode := variantStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to get product variants",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(variants, "Product variants retrieved successfully"))
+}
+
+// GetByID
+// @Summary Get a product variant by ID
+// @Description Get detailed information about a single variant of a product
+// @Tags product-variants
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param variant_id path string true "Variant ID"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductVariantResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/variants/{variant_id} [get]
+func (h *ProductVariantHandler) GetByID(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productVariant.GetByID")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	variantID, err := uuid.Parse(c.Param("variant_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid variant ID",
+			[]string{err.Error()},
+		))
+	}
+
+	variant, err := h.service.GetByID(ctx, productID, variantID)
+	if err != nil {
+		h.logger.Error("failed to get product variant", zap.Error(err))
+		statusCode := variantStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to get product variant",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(variant, "Product variant retrieved successfully"))
+}
+
+// Update
+// @Summary Update a product variant
+// @Description Update a variant's SKU, attributes, price and stock
+// @Tags product-variants
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param variant_id path string true "Variant ID"
+// @Param variant body entity.UpdateProductVariantRequest true "Product variant update request"
+// @Success 200 {object} response_formatter.Response{data=entity.ProductVariantResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/variants/{variant_id} [put]
+func (h *ProductVariantHandler) Update(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productVariant.Update")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	variantID, err := uuid.Parse(c.Param("variant_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid variant ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.UpdateProductVariantRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	variant, err := h.service.Update(ctx, productID, variantID, req)
+	if err != nil {
+		h.logger.Error("failed to update product variant", zap.Error(err))
+		statusCode := variantStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to update product variant",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(variant, "Product variant updated successfully"))
+}
+
+// Delete
+// @Summary Delete a product variant
+// @Description Delete a single variant of a product
+// @Tags product-variants
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param variant_id path string true "Variant ID"
+// @Success 200 {object} response_formatter.Response
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/variants/{variant_id} [delete]
+func (h *ProductVariantHandler) Delete(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.productVariant.Delete")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	variantID, err := uuid.Parse(c.Param("variant_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid variant ID",
+			[]string{err.Error()},
+		))
+	}
+
+	err = h.service.Delete(ctx, productID, variantID)
+	if err != nil {
+		h.logger.Error("failed to delete product variant", zap.Error(err))
+		statusCode := variantStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to delete product variant",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(nil, "Product variant deleted successfully"))
+}
+
+func variantStatusCode(err error) int {
+	if errors.Is(err, entity.ErrProductVariantNotFound) || err.Error() == "product not found" {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, entity.ErrCurrencyMismatch) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/validator"
+	"Unnispick/utils/response_formatter"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+type PromotionHandler struct {
+	service  entity.PromotionService
+	logger   *zap.Logger
+	tracer   *tracing.Tracer
+	validate *validator.Validator
+}
+
+func NewPromotionHandler(
+	service entity.PromotionService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+	validate *validator.Validator,
+) *PromotionHandler {
+	return &PromotionHandler{
+		service:  service,
+		logger:   logger,
+		tracer:   tracer,
+		validate: validate,
+	}
+}
+
+// Create
+// @Summary Create a new promotion
+// @Description Create a percentage or fixed discount on a product, a brand or a category for a period of time
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param promotion body entity.CreatePromotionRequest true "Promotion creation request"
+// @Success 201 {object} response_formatter.Response{data=entity.PromotionResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /promotions [post]
+func (h *PromotionHandler) Create(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.promotion.Create")
+	defer span.End()
+
+	var req entity.CreatePromotionRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	promotion, err := h.service.Create(ctx, req)
+	if err != nil {
+		h.logger.Error("failed to create promotion", zap.Error(err))
+		statusCode := promotionStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to create promotion",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusCreated, response_formatter.Created(promotion, "Promotion created successfully"))
+}
+
+// GetByID
+// @Summary Get a promotion by ID
+// @Description Get detailed information about a promotion by its ID
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param id path string true "Promotion ID"
+// @Success 200 {object} response_formatter.Response{data=entity.PromotionResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /promotions/{id} [get]
+func (h *PromotionHandler) GetByID(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.promotion.GetByID")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid promotion ID",
+			[]string{err.Error()},
+		))
+	}
+
+	promotion, err := h.service.GetByID(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to get promotion", zap.Error(err))
+		statusCode := promotionStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to get promotion",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(promotion, "Promotion retrieved successfully"))
+}
+
+// GetAll
+// @Summary Get all promotions
+// @Description Get a paginated list of promotions with optional scope and running-now filters
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param scope_type query string false "Filter by scope type (product, brand or category)"
+// @Param scope_id query string false "Filter by the product, brand or category ID"
+// @Param active query bool false "Only promotions running now"
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 10)"
+// @Success 200 {object} response_formatter.Response{data=[]entity.PromotionResponse}
+// @Failure 500 {object} response_formatter.Response
+// @Router /promotions [get]
+func (h *PromotionHandler) GetAll(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.promotion.GetAll")
+	defer span.End()
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+
+	page, perPage = response_formatter.ValidatePagination(page, perPage)
+	filter := entity.PromotionFilterRequest{
+		ScopeType: c.QueryParam("scope_type"),
+		Page:      page,
+		PerPage:   perPage,
+	}
+	if scopeID := c.QueryParam("scope_id"); scopeID != "" {
+		if id, err := uuid.Parse(scopeID); err == nil {
+			filter.ScopeID = id
+		}
+	}
+	if active, err := strconv.ParseBool(c.QueryParam("active")); err == nil {
+		filter.ActiveOnly = active
+	}
+
+	promotions, total, err := h.service.GetAll(ctx, filter)
+	if err != nil {
+		h.logger.Error("failed to get promotions", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to get promotions",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.WithPagination(
+		promotions,
+		"Promotions retrieved successfully",
+		page,
+		perPage,
+		total,
+	))
+}
+
+// Update
+// @Summary Update a promotion
+// @Description Replace the discount, scope, period and stacking rules of a promotion
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param id path string true "Promotion ID"
+// @Param promotion body entity.UpdatePromotionRequest true "Promotion update request"
+// @Success 200 {object} response_formatter.Response{data=entity.PromotionResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /promotions/{id} [put]
+func (h *PromotionHandler) Update(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.promotion.Update")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid promotion ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.UpdatePromotionRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	promotion, err := h.service.Update(ctx, id, req)
+	if err != nil {
+		h.logger.Error("failed to update promotion", zap.Error(err))
+		statusCode := promotionStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to update promotion",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(promotion, "Promotion updated successfully"))
+}
+
+// Delete
+// @Summary Delete a promotion
+// @Description Delete a promotion, products go back to their regular price
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param id path string true "Promotion ID"
+// @Success 200 {object} response_formatter.Response
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /promotions/{id} [delete]
+func (h *PromotionHandler) Delete(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.promotion.Delete")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid promotion ID",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.service.Delete(ctx, id); err != nil {
+		h.logger.Error("failed to delete promotion", zap.Error(err))
+		statusCode := promotionStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to delete promotion",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(nil, "Promotion deleted successfully"))
+}
+
+func promotionStatusCode(err error) int {
+	switch {
+	case errors.Is(err, entity.ErrPromotionNotFound),
+		errors.Is(err, entity.ErrPromotionScopeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrInvalidPercentage),
+		errors.Is(err, entity.ErrInvalidAmountOff),
+		errors.Is(err, entity.ErrInvalidPromotionPeriod):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/validator"
+	"Unnispick/utils/response_formatter"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type ReservationHandler struct {
+	service  entity.ReservationService
+	logger   *zap.Logger
+	tracer   *tracing.Tracer
+	validate *validator.Validator
+}
+
+func NewReservationHandler(
+	service entity.ReservationService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+	validate *validator.Validator,
+) *ReservationHandler {
+	return &ReservationHandler{
+		service:  service,
+		logger:   logger,
+		tracer:   tracer,
+		validate: validate,
+	}
+}
+
+// Create
+// @Summary Reserve product stock
+// @Description Hold units of a product for a limited time without decrementing its quantity
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param reservation body entity.CreateReservationRequest true "Reservation creation request"
+// @Success 201 {object} response_formatter.Response{data=entity.ReservationResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 409 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /products/{id}/reservations [post]
+func (h *ReservationHandler) Create(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.reservation.Create")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid product ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.CreateReservationRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	reservation, err := h.service.Create(ctx, productID, req)
+	if err != nil {
+		h.logger.Error("failed to create reservation", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "product not found" {
+			statusCode = http.StatusNotFound
+		} else if errors.Is(err, entity.ErrInsufficientStock) {
+			statusCode = http.StatusConflict
+		} else if errors.Is(err, entity.ErrInvalidAmount) ||
+			errors.Is(err, entity.ErrReservationTTLTooLong) ||
+			errors.Is(err, entity.ErrBundleStockDerived) {
+			statusCode = http.StatusBadRequest
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to create reservation",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusCreated, response_formatter.Created(reservation, "Reservation created successfully"))
+}
+
+// GetByID
+// @Summary Get a reservation by ID
+// @Description Get detailed information about a stock reservation by its ID
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} response_formatter.Response{data=entity.ReservationResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /reservations/{id} [get]
+func (h *ReservationHandler) GetByID(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.reservation.GetByID")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid reservation ID",
+			[]string{err.Error()},
+		))
+	}
+
+	reservation, err := h.service.GetByID(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to get reservation", zap.Error(err))
+		statusCode := reservationStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to get reservation",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(reservation, "Reservation retrieved successfully"))
+}
+
+// Confirm
+// @Summary Confirm a reservation
+// @Description Turn a pending reservation into a permanent stock decrement
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} response_formatter.Response{data=entity.ReservationResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 409 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /reservations/{id}/confirm [post]
+func (h *ReservationHandler) Confirm(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.reservation.Confirm")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid reservation ID",
+			[]string{err.Error()},
+		))
+	}
+
+	reservation, err := h.service.Confirm(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to confirm reservation", zap.Error(err))
+		statusCode := reservationStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to confirm reservation",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(reservation, "Reservation confirmed successfully"))
+}
+
+// Release
+// @Summary Release a reservation
+// @Description Give the units held by a pending reservation back to the available stock
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} response_formatter.Response{data=entity.ReservationResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 409 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /reservations/{id}/release [post]
+func (h *ReservationHandler) Release(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.reservation.Release")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid reservation ID",
+			[]string{err.Error()},
+		))
+	}
+
+	reservation, err := h.service.Release(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to release reservation", zap.Error(err))
+		statusCode := reservationStatusCode(err)
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to release reservation",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(reservation, "Reservation released successfully"))
+}
+
+func reservationStatusCode(err error) int {
+	switch {
+	case errors.Is(err, entity.ErrReservationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrReservationNotPending),
+		errors.Is(err, entity.ErrReservationExpired),
+		errors.Is(err, entity.ErrInsufficientStock):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/utils/response_formatter"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"path"
+)
+
+// slugMoved answers a lookup by an old slug with a 301 to the same route under the current slug
+func slugMoved(c echo.Context, moved *entity.SlugMovedError, message string) error {
+	location := path.Join(path.Dir(c.Request().URL.Path), moved.Slug)
+	c.Response().Header().Set(echo.HeaderLocation, location)
+	return c.JSON(http.StatusMovedPermanently, response_formatter.MovedPermanently(
+		entity.SlugRedirectResponse{Slug: moved.Slug, Location: location},
+		message,
+	))
+}
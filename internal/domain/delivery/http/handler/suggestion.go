@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/utils/response_formatter"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type SuggestionHandler struct {
+	service entity.SuggestionService
+	logger  *zap.Logger
+	tracer  *tracing.Tracer
+}
+
+func NewSuggestionHandler(
+	service entity.SuggestionService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) *SuggestionHandler {
+	return &SuggestionHandler{
+		service: service,
+		logger:  logger,
+		tracer:  tracer,
+	}
+}
+
+// Suggest
+// @Summary Suggest brands and products
+// @Description Suggest the brands and active products whose name resembles the typed text, tolerating typos such as "innisfre" for Innisfree. Brands and products are mixed, the most similar first.
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "Typed text"
+// @Param limit query int false "Maximum number of suggestions (default: 10, max: 20)"
+// @Success 200 {object} response_formatter.Response{data=[]entity.Suggestion}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /suggest [get]
+func (h *SuggestionHandler) Suggest(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.suggestion.Suggest")
+	defer span.End()
+
+	text := strings.TrimSpace(c.QueryParam("q"))
+	if text == "" || utf8.RuneCountInString(text) > entity.MaxSuggestionQueryLength {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid q parameter",
+			[]string{fmt.Sprintf("q must be between 1 and %d characters", entity.MaxSuggestionQueryLength)},
+		))
+	}
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	suggestions, err := h.service.Suggest(ctx, text, limit)
+	if err != nil {
+		h.logger.Error("failed to get suggestions", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to get suggestions",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(suggestions, "Suggestions retrieved successfully"))
+}
@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/validator"
+	"Unnispick/utils/response_formatter"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+type TagHandler struct {
+	service  entity.TagService
+	logger   *zap.Logger
+	tracer   *tracing.Tracer
+	validate *validator.Validator
+}
+
+func NewTagHandler(
+	service entity.TagService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+	validate *validator.Validator,
+) *TagHandler {
+	return &TagHandler{
+		service:  service,
+		logger:   logger,
+		tracer:   tracer,
+		validate: validate,
+	}
+}
+
+// Create
+// @Summary Create a new tag
+// @Description Create a new free-form product tag, names are normalized to lowercase-hyphenated form
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tag body entity.CreateTagRequest true "Tag creation request"
+// @Success 201 {object} response_formatter.Response{data=entity.TagResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /tags [post]
+func (h *TagHandler) Create(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.tag.Create")
+	defer span.End()
+
+	var req entity.CreateTagRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	tag, err := h.service.Create(ctx, req)
+	if err != nil {
+		h.logger.Error("failed to create tag", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to create tag",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusCreated, response_formatter.Created(tag, "Tag created successfully"))
+}
+
+// GetAll
+// @Summary Get all tags with pagination
+// @Description Get a list of all tags with pagination support
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 10)"
+// @Param search query string false "Search term for tag name"
+// @Success 200 {object} response_formatter.Response{data=[]entity.TagResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /tags [get]
+func (h *TagHandler) GetAll(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.tag.GetAll")
+	defer span.End()
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+	search := c.QueryParam("search")
+
+	page, perPage = response_formatter.ValidatePagination(page, perPage)
+	filter := entity.TagFilterRequest{
+		Search:  search,
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	tags, total, err := h.service.GetAll(ctx, filter)
+	if err != nil {
+		h.logger.Error("failed to get tags", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, response_formatter.Error(
+			http.StatusInternalServerError,
+			"Failed to get tags",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.WithPagination(
+		tags,
+		"Tags retrieved successfully",
+		page,
+		perPage,
+		total,
+	))
+}
+
+// Update
+// @Summary Update a tag
+// @Description Rename a tag, the new name is applied to every tagged product
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param tag body entity.UpdateTagRequest true "Tag update request"
+// @Success 200 {object} response_formatter.Response{data=entity.TagResponse}
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /tags/{id} [put]
+func (h *TagHandler) Update(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.tag.Update")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid tag ID",
+			[]string{err.Error()},
+		))
+	}
+
+	var req entity.UpdateTagRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid request body",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.validate.Validate(ctx, req); err != nil {
+		validationErrors := h.validate.ExtractValidationErrors(err)
+		var errorMessages []string
+		for _, ve := range validationErrors {
+			errorMessages = append(errorMessages, ve.Message)
+		}
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Validation failed",
+			errorMessages,
+		))
+	}
+
+	tag, err := h.service.Update(ctx, id, req)
+	if err != nil {
+		h.logger.Error("failed to update tag", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "tag not found" {
+			statusCode = http.StatusNotFound
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to update tag",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(tag, "Tag updated successfully"))
+}
+
+// Delete
+// @Summary Delete a tag
+// @Description Delete a tag by its ID and remove it from every product
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Success 200 {object} response_formatter.Response
+// @Failure 400 {object} response_formatter.Response
+// @Failure 404 {object} response_formatter.Response
+// @Failure 500 {object} response_formatter.Response
+// @Router /tags/{id} [delete]
+func (h *TagHandler) Delete(c echo.Context) error {
+	ctx, span := h.tracer.StartFromEcho(c, "handler.tag.Delete")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response_formatter.Error(
+			http.StatusBadRequest,
+			"Invalid tag ID",
+			[]string{err.Error()},
+		))
+	}
+
+	if err := h.service.Delete(ctx, id); err != nil {
+		h.logger.Error("failed to delete tag", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "tag not found" {
+			statusCode = http.StatusNotFound
+		}
+		return c.JSON(statusCode, response_formatter.Error(
+			statusCode,
+			"Failed to delete tag",
+			[]string{err.Error()},
+		))
+	}
+
+	return c.JSON(http.StatusOK, response_formatter.Success(nil, "Tag deleted successfully"))
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"Unnispick/internal/domain/entity"
+	"errors"
+	"net/http"
+)
+
+func restoreStatusCode(err error) int {
+	switch {
+	case errors.Is(err, entity.ErrNotInTrash):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrRestoreNameTaken),
+		errors.Is(err, entity.ErrRestoreBrandTrashed):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// multipartOverhead is the room left above a file size limit for the multipart framing and the
+// form fields sent along with the file
+const multipartOverhead = 64 << 10
+
+// limitUpload stops reading the request body once it passes limit and the multipart framing, so
+// an oversized upload is refused before it is parsed and spooled to disk. A limit of 0 reads the
+// whole body.
+func limitUpload(c echo.Context, limit int64) {
+	if limit <= 0 {
+		return
+	}
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, limit+multipartOverhead)
+}
+
+// isBodyTooLarge reports whether reading the body stopped at the limitUpload limit
+func isBodyTooLarge(err error) bool {
+	var maxErr *http.MaxBytesError
+	return errors.As(err, &maxErr)
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"Unnispick/pkg/actor"
+	"github.com/labstack/echo/v4"
+	"strings"
+)
+
+// Actor stores the caller identity from the X-Actor header in the request context
+func Actor() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if name := strings.TrimSpace(req.Header.Get(actor.Header)); name != "" {
+				c.SetRequest(req.WithContext(actor.WithActor(req.Context(), name)))
+			}
+			return next(c)
+		}
+	}
+}
@@ -3,29 +3,75 @@ package router
 import (
 	"Unnispick/internal/domain/delivery/http/handler"
 	"Unnispick/internal/domain/delivery/http/middleware"
+	"Unnispick/pkg/media"
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type Router struct {
-	e               *echo.Echo
-	brandHandler    *handler.BrandHandler
-	productHandler  *handler.ProductHandler
-	telemetryMiddle *middleware.TelemetryMiddleware
+	e                   *echo.Echo
+	brandHandler        *handler.BrandHandler
+	productHandler      *handler.ProductHandler
+	reservationHandler  *handler.ReservationHandler
+	variantHandler      *handler.ProductVariantHandler
+	categoryHandler     *handler.CategoryHandler
+	tagHandler          *handler.TagHandler
+	ingredientHandler   *handler.IngredientHandler
+	imageHandler        *handler.ProductImageHandler
+	priceListHandler    *handler.PriceListHandler
+	exchangeHandler     *handler.ExchangeRateHandler
+	productPriceHandler *handler.ProductPriceHandler
+	promotionHandler    *handler.PromotionHandler
+	auditHandler        *handler.AuditHandler
+	importHandler       *handler.ProductImportHandler
+	exportHandler       *handler.ProductExportHandler
+	suggestionHandler   *handler.SuggestionHandler
+	mediaStorage        media.MediaStorage
+	telemetryMiddle     *middleware.TelemetryMiddleware
 }
 
 func NewRouter(
 	e *echo.Echo,
 	brandHandler *handler.BrandHandler,
 	productHandler *handler.ProductHandler,
+	reservationHandler *handler.ReservationHandler,
+	variantHandler *handler.ProductVariantHandler,
+	categoryHandler *handler.CategoryHandler,
+	tagHandler *handler.TagHandler,
+	ingredientHandler *handler.IngredientHandler,
+	imageHandler *handler.ProductImageHandler,
+	priceListHandler *handler.PriceListHandler,
+	exchangeHandler *handler.ExchangeRateHandler,
+	productPriceHandler *handler.ProductPriceHandler,
+	promotionHandler *handler.PromotionHandler,
+	auditHandler *handler.AuditHandler,
+	importHandler *handler.ProductImportHandler,
+	exportHandler *handler.ProductExportHandler,
+	suggestionHandler *handler.SuggestionHandler,
+	mediaStorage media.MediaStorage,
 	telemetryMiddle *middleware.TelemetryMiddleware,
 ) *Router {
 	return &Router{
-		e:               e,
-		brandHandler:    brandHandler,
-		productHandler:  productHandler,
-		telemetryMiddle: telemetryMiddle,
+		e:                   e,
+		brandHandler:        brandHandler,
+		productHandler:      productHandler,
+		reservationHandler:  reservationHandler,
+		variantHandler:      variantHandler,
+		categoryHandler:     categoryHandler,
+		tagHandler:          tagHandler,
+		ingredientHandler:   ingredientHandler,
+		imageHandler:        imageHandler,
+		priceListHandler:    priceListHandler,
+		exchangeHandler:     exchangeHandler,
+		productPriceHandler: productPriceHandler,
+		promotionHandler:    promotionHandler,
+		auditHandler:        auditHandler,
+		importHandler:       importHandler,
+		exportHandler:       exportHandler,
+		suggestionHandler:   suggestionHandler,
+		mediaStorage:        mediaStorage,
+		telemetryMiddle:     telemetryMiddle,
 	}
 }
 
@@ -33,8 +79,12 @@ func (r *Router) Setup() {
 	// Middleware
 	r.e.Use(echoMiddleware.Logger())
 	r.e.Use(echoMiddleware.Recover())
-	r.e.Use(echoMiddleware.CORS())
+	r.e.Use(echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
+		// Browsers hide the ETag from scripts unless it is exposed, clients need it for If-Match
+		ExposeHeaders: []string{"ETag"},
+	}))
 	r.e.Use(r.telemetryMiddle.Middleware())
+	r.e.Use(middleware.Actor())
 
 	// Metrics endpoint
 	r.e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
@@ -46,6 +96,11 @@ func (r *Router) Setup() {
 		})
 	})
 
+	// Uploaded media, when the storage keeps files on this server
+	if fs, ok := r.mediaStorage.(media.FileServer); ok {
+		r.e.Static(fs.MountPath(), fs.Dir())
+	}
+
 	// API v1 group
 	v1 := r.e.Group("/api/v1")
 
@@ -53,17 +108,112 @@ func (r *Router) Setup() {
 	brands := v1.Group("/brands")
 	brands.POST("", r.brandHandler.Create)
 	brands.GET("", r.brandHandler.GetAll)
+	brands.GET("/by-slug/:slug", r.brandHandler.GetBySlug)
+	brands.GET("/trash", r.brandHandler.GetTrash)
 	brands.GET("/:id", r.brandHandler.GetByID)
 	brands.PUT("/:id", r.brandHandler.Update)
+	brands.PATCH("/:id", r.brandHandler.Patch)
 	brands.DELETE("/:id", r.brandHandler.Delete)
+	brands.POST("/:id/restore", r.brandHandler.Restore)
+
+	// Category routes
+	categories := v1.Group("/categories")
+	categories.POST("", r.categoryHandler.Create)
+	categories.GET("", r.categoryHandler.GetAll)
+	categories.GET("/:id", r.categoryHandler.GetByID)
+	categories.PUT("/:id", r.categoryHandler.Update)
+	categories.DELETE("/:id", r.categoryHandler.Delete)
+
+	// Tag routes
+	tags := v1.Group("/tags")
+	tags.POST("", r.tagHandler.Create)
+	tags.GET("", r.tagHandler.GetAll)
+	tags.PUT("/:id", r.tagHandler.Update)
+	tags.DELETE("/:id", r.tagHandler.Delete)
+
+	// Ingredient routes
+	ingredients := v1.Group("/ingredients")
+	ingredients.POST("", r.ingredientHandler.Create)
+	ingredients.GET("", r.ingredientHandler.GetAll)
+	ingredients.GET("/:id", r.ingredientHandler.GetByID)
+	ingredients.PUT("/:id", r.ingredientHandler.Update)
+	ingredients.DELETE("/:id", r.ingredientHandler.Delete)
+
+	// Promotion routes
+	promotions := v1.Group("/promotions")
+	promotions.POST("", r.promotionHandler.Create)
+	promotions.GET("", r.promotionHandler.GetAll)
+	promotions.GET("/:id", r.promotionHandler.GetByID)
+	promotions.PUT("/:id", r.promotionHandler.Update)
+	promotions.DELETE("/:id", r.promotionHandler.Delete)
 
 	// Product routes
 	products := v1.Group("/products")
 	products.POST("", r.productHandler.Create)
+	products.POST("/bundles", r.productHandler.CreateBundle)
+	products.POST("/bulk", r.productHandler.BulkCreate)
+	products.PUT("/bulk", r.productHandler.BulkUpdate)
+	products.DELETE("/bulk", r.productHandler.BulkDelete)
+	products.POST("/import", r.importHandler.Import)
 	products.GET("", r.productHandler.GetAll)
+	products.GET("/export", r.exportHandler.Export)
+	products.GET("/by-slug/:slug", r.productHandler.GetBySlug)
+	products.GET("/trash", r.productHandler.GetTrash)
 	products.GET("/:id", r.productHandler.GetByID)
 	products.PUT("/:id", r.productHandler.Update)
+	products.PATCH("/:id", r.productHandler.Patch)
 	products.DELETE("/:id", r.productHandler.Delete)
+	products.POST("/:id/restore", r.productHandler.Restore)
+	products.POST("/:id/stock/increase", r.productHandler.IncreaseStock)
+	products.POST("/:id/stock/decrease", r.productHandler.DecreaseStock)
+	products.GET("/:id/stock-movements", r.productHandler.GetStockMovements)
+	products.PUT("/:id/ingredients", r.productHandler.SetIngredients)
+	products.PUT("/:id/components", r.productHandler.SetBundleComponents)
+	products.POST("/:id/publish", r.productHandler.Publish)
+	products.POST("/:id/discontinue", r.productHandler.Discontinue)
+	products.POST("/:id/archive", r.productHandler.Archive)
+	products.GET("/:id/prices", r.priceListHandler.GetAll)
+	products.PUT("/:id/prices", r.priceListHandler.SetPrice)
+	products.DELETE("/:id/prices/:currency", r.priceListHandler.Delete)
+	products.GET("/:id/price-history", r.productPriceHandler.GetHistory)
+	products.POST("/:id/reservations", r.reservationHandler.Create)
+
+	// Scheduled price change routes
+	priceSchedules := products.Group("/:id/price-schedules")
+	priceSchedules.POST("", r.productPriceHandler.Schedule)
+	priceSchedules.GET("", r.productPriceHandler.GetScheduled)
+	priceSchedules.DELETE("/:schedule_id", r.productPriceHandler.CancelScheduled)
+
+	// Product variant routes
+	variants := products.Group("/:id/variants")
+	variants.POST("", r.variantHandler.Create)
+	variants.GET("", r.variantHandler.GetAll)
+	variants.GET("/:variant_id", r.variantHandler.GetByID)
+	variants.PUT("/:variant_id", r.variantHandler.Update)
+	variants.DELETE("/:variant_id", r.variantHandler.Delete)
+
+	// Product image routes
+	images := products.Group("/:id/images")
+	images.POST("", r.imageHandler.Upload)
+	images.GET("", r.imageHandler.GetAll)
+	images.PUT("/order", r.imageHandler.Reorder)
+	images.POST("/:image_id/primary", r.imageHandler.SetPrimary)
+	images.DELETE("/:image_id", r.imageHandler.Delete)
+
+	// Exchange rate routes
+	v1.GET("/exchange-rates", r.exchangeHandler.GetAll)
+	admin := v1.Group("/admin")
+	admin.PUT("/exchange-rates", r.exchangeHandler.Upload)
+
+	// Reservation routes
+	reservations := v1.Group("/reservations")
+	reservations.GET("/:id", r.reservationHandler.GetByID)
+	reservations.POST("/:id/confirm", r.reservationHandler.Confirm)
+	reservations.POST("/:id/release", r.reservationHandler.Release)
+
+	// Audit log routes
+	v1.GET("/audit", r.auditHandler.GetAll)
+	v1.GET("/suggest", r.suggestionHandler.Suggest)
 
 	// When we add Swagger, we'll add it here
 	// r.e.GET("/swagger/*", echoSwagger.WrapHandler)
@@ -0,0 +1,193 @@
+package entity
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	AuditEntityBrand   = "brand"
+	AuditEntityProduct = "product"
+
+	AuditActionCreate         = "create"
+	AuditActionUpdate         = "update"
+	AuditActionDelete         = "delete"
+	AuditActionRestore        = "restore"
+	AuditActionPublish        = "publish"
+	AuditActionDiscontinue    = "discontinue"
+	AuditActionArchive        = "archive"
+	AuditActionStockIncrease  = "stock_increase"
+	AuditActionStockDecrease  = "stock_decrease"
+	AuditActionSetIngredients = "set_ingredients"
+	AuditActionSetComponents  = "set_components"
+	AuditActionApplyPrice     = "apply_scheduled_price"
+)
+
+type (
+	AuditLog struct {
+		ID         uuid.UUID    `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+		Actor      string       `json:"actor" gorm:"type:varchar(255);not null"`
+		Action     string       `json:"action" gorm:"type:varchar(50);not null"`
+		EntityType string       `json:"entity_type" gorm:"column:entity_type;type:varchar(50);not null"`
+		EntityID   uuid.UUID    `json:"entity_id" gorm:"column:entity_id;type:uuid;not null"`
+		Changes    AuditChanges `json:"changes" gorm:"type:jsonb;not null"`
+		TraceID    string       `json:"trace_id" gorm:"column:trace_id;type:varchar(32);not null;default:''"`
+		CreatedAt  time.Time    `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+	}
+
+	// AuditSnapshot holds the audited fields of an entity, keyed by their JSON name
+	AuditSnapshot map[string]interface{}
+
+	// AuditChange is the JSON value of a field before and after a change, a side is left out
+	// when the entity did not exist
+	AuditChange struct {
+		Old json.RawMessage `json:"old,omitempty"`
+		New json.RawMessage `json:"new,omitempty"`
+	}
+
+	// AuditChanges maps each changed field to its old and new value
+	AuditChanges map[string]AuditChange
+
+	AuditRepository interface {
+		Create(ctx context.Context, log *AuditLog) error
+		GetAllWithFilter(ctx context.Context, filter AuditFilterRepository) (logs []AuditLog, count int64, err error)
+	}
+
+	AuditService interface {
+		// Record writes an audit entry for a change between two snapshots, nil for an entity that
+		// did not exist. Failures are logged rather than returned since the change already happened.
+		Record(ctx context.Context, action, entityType string, entityID uuid.UUID, before, after AuditSnapshot)
+		GetAll(ctx context.Context, filter AuditFilterRequest) ([]AuditLogResponse, int64, error)
+	}
+
+	AuditFilterRequest struct {
+		EntityType string     `query:"entity"`
+		EntityID   uuid.UUID  `query:"entity_id"`
+		Actor      string     `query:"actor"`
+		Action     string     `query:"action"`
+		From       *time.Time `query:"from"`
+		To         *time.Time `query:"to"`
+		Page       int        `query:"page"`
+		PerPage    int        `query:"per_page"`
+	}
+
+	AuditFilterRepository struct {
+		EntityType string
+		EntityID   uuid.UUID
+		Actor      string
+		Action     string
+		From       *time.Time
+		To         *time.Time
+		Limit      int
+		Offset     int
+	}
+
+	AuditLogResponse struct {
+		ID         uuid.UUID    `json:"id"`
+		Actor      string       `json:"actor"`
+		Action     string       `json:"action"`
+		EntityType string       `json:"entity"`
+		EntityID   uuid.UUID    `json:"entity_id"`
+		Changes    AuditChanges `json:"changes"`
+		TraceID    string       `json:"trace_id,omitempty"`
+		CreatedAt  string       `json:"created_at"`
+	}
+)
+
+func (*AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (c *AuditChanges) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into audit changes", value)
+	}
+	return json.Unmarshal(data, c)
+}
+
+// DiffAuditSnapshots lists the fields whose JSON value differs between before and after
+func DiffAuditSnapshots(before, after AuditSnapshot) (AuditChanges, error) {
+	changes := AuditChanges{}
+
+	for field, value := range before {
+		old, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", field, err)
+		}
+
+		change := AuditChange{Old: old}
+		if next, ok := after[field]; ok {
+			if change.New, err = json.Marshal(next); err != nil {
+				return nil, fmt.Errorf("failed to encode %s: %w", field, err)
+			}
+		}
+
+		if !bytes.Equal(change.Old, change.New) {
+			changes[field] = change
+		}
+	}
+
+	for field, value := range after {
+		if _, ok := before[field]; ok {
+			continue
+		}
+		next, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", field, err)
+		}
+		changes[field] = AuditChange{New: next}
+	}
+
+	return changes, nil
+}
+
+func (req AuditFilterRequest) ToAuditFilterRepo() AuditFilterRepository {
+	return AuditFilterRepository{
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		Actor:      req.Actor,
+		Action:     req.Action,
+		From:       req.From,
+		To:         req.To,
+		Limit:      req.PerPage,
+		Offset:     (req.Page - 1) * req.PerPage,
+	}
+}
+
+func (l *AuditLog) ToResponseDTO() *AuditLogResponse {
+	return &AuditLogResponse{
+		ID:         l.ID,
+		Actor:      l.Actor,
+		Action:     l.Action,
+		EntityType: l.EntityType,
+		EntityID:   l.EntityID,
+		Changes:    l.Changes,
+		TraceID:    l.TraceID,
+		CreatedAt:  l.CreatedAt.Format(time.RFC3339),
+	}
+}
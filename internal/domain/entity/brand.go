@@ -2,64 +2,110 @@ package entity
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
 	"time"
 )
 
 type (
 	Brand struct {
-		ID        uuid.UUID  `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
-		BrandName string     `json:"brand_name" validate:"required,min=1,max=255" gorm:"column:brand_name;type:varchar(255);not null"`
-		CreatedAt time.Time  `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
-		UpdatedAt time.Time  `json:"updated_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
-		DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index;type:timestamp with time zone"`
-		Products  []Product  `json:"products,omitempty" gorm:"foreignKey:BrandID"`
+		ID          uuid.UUID      `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+		BrandName   string         `json:"brand_name" validate:"required,min=1,max=255" gorm:"column:brand_name;type:varchar(255);not null"`
+		Slug        string         `json:"slug" gorm:"type:varchar(255);not null;uniqueIndex"`
+		Description string         `json:"description" gorm:"type:text;not null;default:''"`
+		LogoURL     string         `json:"logo_url" gorm:"column:logo_url;type:varchar(2048);not null;default:''"`
+		Country     string         `json:"country" gorm:"type:varchar(2);not null;default:'';index"`
+		Website     string         `json:"website" gorm:"type:varchar(2048);not null;default:''"`
+		SocialLinks SocialLinks    `json:"social_links" gorm:"column:social_links;type:jsonb;not null;default:'{}'"`
+		Version     int            `json:"version" gorm:"type:integer;not null;default:1"`
+		CreatedAt   time.Time      `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		UpdatedAt   time.Time      `json:"updated_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index;type:timestamp with time zone"`
+		Products    []Product      `json:"products,omitempty" gorm:"foreignKey:BrandID"`
 	}
 
+	// SocialLinks maps a network name such as "instagram" to the brand's profile URL
+	SocialLinks map[string]string
+
 	BrandRepository interface {
 		Create(ctx context.Context, brand *Brand) error
 		GetByID(ctx context.Context, id uuid.UUID) (*Brand, error)
 		GetAllWithFilter(ctx context.Context, filter BrandFilterRepository) (brands []Brand, count int64, err error)
 		Update(ctx context.Context, brand *Brand) error
-		Delete(ctx context.Context, id uuid.UUID) error
+		Delete(ctx context.Context, id uuid.UUID, version int) error
 		ExistsByID(ctx context.Context, id uuid.UUID) (bool, error)
 		GetByName(ctx context.Context, name string) (*Brand, error)
 		ExistsByName(ctx context.Context, name string) (bool, error)
+		GetBySlug(ctx context.Context, slug string) (*Brand, error)
+		IsSlugTaken(ctx context.Context, slug string, exceptID uuid.UUID) (bool, error)
+		GetTrashedByID(ctx context.Context, id uuid.UUID) (*Brand, error)
+		GetAllTrashed(ctx context.Context, filter TrashFilterRepository) (brands []Brand, count int64, err error)
+		Restore(ctx context.Context, id uuid.UUID) error
+		Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 	}
 
 	BrandService interface {
 		Create(ctx context.Context, req CreateBrandRequest) (*BrandResponse, error)
 		GetByID(ctx context.Context, id uuid.UUID) (*BrandResponse, error)
+		GetBySlug(ctx context.Context, slug string) (*BrandResponse, error)
 		GetAll(ctx context.Context, filter BrandFilterRequest) ([]BrandResponse, int64, error)
-		Update(ctx context.Context, id uuid.UUID, req UpdateBrandRequest) (*BrandResponse, error)
-		Delete(ctx context.Context, id uuid.UUID) error
+		Update(ctx context.Context, id uuid.UUID, version int, req UpdateBrandRequest) (*BrandResponse, error)
+		Delete(ctx context.Context, id uuid.UUID, version int) error
+		GetTrash(ctx context.Context, filter TrashFilterRequest) ([]BrandResponse, int64, error)
+		Restore(ctx context.Context, id uuid.UUID) (*BrandResponse, error)
 	}
 
 	BrandFilterRequest struct {
 		Search  string `query:"search"`
+		Country string `query:"country"`
 		Page    int    `query:"page"`
 		PerPage int    `query:"per_page"`
 	}
 
 	BrandFilterRepository struct {
-		Search string
-		Limit  int
-		Offset int
+		Search  string
+		Country string
+		Limit   int
+		Offset  int
 	}
 
 	CreateBrandRequest struct {
-		BrandName string `json:"brand_name" validate:"required,min=1,max=255"`
+		BrandName   string      `json:"brand_name" validate:"required,min=1,max=255"`
+		Slug        string      `json:"slug" validate:"omitempty,max=200,slug"`
+		Description string      `json:"description" validate:"omitempty,max=5000"`
+		LogoURL     string      `json:"logo_url" validate:"omitempty,max=2048,http_url"`
+		Country     string      `json:"country" validate:"omitempty,country"`
+		Website     string      `json:"website" validate:"omitempty,max=2048,http_url"`
+		SocialLinks SocialLinks `json:"social_links" validate:"omitempty,max=20,dive,keys,max=50,slug,endkeys,max=2048,http_url"`
 	}
 
 	UpdateBrandRequest struct {
-		BrandName string `json:"brand_name" validate:"required,min=1,max=255"`
+		BrandName   string      `json:"brand_name" validate:"required,min=1,max=255"`
+		Slug        string      `json:"slug" validate:"omitempty,max=200,slug"`
+		Description string      `json:"description" validate:"omitempty,max=5000"`
+		LogoURL     string      `json:"logo_url" validate:"omitempty,max=2048,http_url"`
+		Country     string      `json:"country" validate:"omitempty,country"`
+		Website     string      `json:"website" validate:"omitempty,max=2048,http_url"`
+		SocialLinks SocialLinks `json:"social_links" validate:"omitempty,max=20,dive,keys,max=50,slug,endkeys,max=2048,http_url"`
 	}
 
 	BrandResponse struct {
-		ID        uuid.UUID `json:"id"`
-		BrandName string    `json:"brand_name"`
-		CreatedAt string    `json:"created_at"`
-		UpdatedAt string    `json:"updated_at"`
+		ID          uuid.UUID   `json:"id"`
+		BrandName   string      `json:"brand_name"`
+		Slug        string      `json:"slug"`
+		Description string      `json:"description,omitempty"`
+		LogoURL     string      `json:"logo_url,omitempty"`
+		Country     string      `json:"country,omitempty"`
+		Website     string      `json:"website,omitempty"`
+		SocialLinks SocialLinks `json:"social_links,omitempty"`
+		Version     int         `json:"version"`
+		CreatedAt   string      `json:"created_at"`
+		UpdatedAt   string      `json:"updated_at"`
+		DeletedAt   string      `json:"deleted_at,omitempty"`
 	}
 )
 
@@ -67,17 +113,64 @@ func (*Brand) TableName() string {
 	return "brands"
 }
 
+func (l SocialLinks) Value() (driver.Value, error) {
+	if l == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (l *SocialLinks) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into social links", value)
+	}
+	return json.Unmarshal(data, l)
+}
+
+// Equal reports whether both hold the same links, an empty set equals no links
+func (l SocialLinks) Equal(other SocialLinks) bool {
+	if len(l) != len(other) {
+		return false
+	}
+	for network, url := range l {
+		if otherURL, ok := other[network]; !ok || otherURL != url {
+			return false
+		}
+	}
+	return true
+}
+
 func (req BrandFilterRequest) ToBrandFilterRepo() BrandFilterRepository {
 	return BrandFilterRepository{
-		Search: req.Search,
-		Limit:  req.PerPage,
-		Offset: (req.Page - 1) * req.PerPage,
+		Search:  req.Search,
+		Country: strings.ToUpper(req.Country),
+		Limit:   req.PerPage,
+		Offset:  (req.Page - 1) * req.PerPage,
 	}
 }
 
 func (req *CreateBrandRequest) ToBrandEntity() *Brand {
 	return &Brand{
-		BrandName: req.BrandName,
+		BrandName:   req.BrandName,
+		Slug:        req.Slug,
+		Description: req.Description,
+		LogoURL:     req.LogoURL,
+		Country:     strings.ToUpper(req.Country),
+		Website:     req.Website,
+		SocialLinks: req.SocialLinks,
 	}
 }
 
@@ -85,12 +178,56 @@ func (b *Brand) UpdateFromRequest(req UpdateBrandRequest) {
 	if req.BrandName != "" {
 		b.BrandName = req.BrandName
 	}
+	if req.Slug != "" {
+		b.Slug = req.Slug
+	}
+	b.Description = req.Description
+	b.LogoURL = req.LogoURL
+	b.Country = strings.ToUpper(req.Country)
+	b.Website = req.Website
+	b.SocialLinks = req.SocialLinks
 }
 
 func (b *Brand) ToResponseDTO() *BrandResponse {
 	return &BrandResponse{
-		ID:        b.ID,
-		BrandName: b.BrandName,
+		ID:          b.ID,
+		BrandName:   b.BrandName,
+		Slug:        b.Slug,
+		Description: b.Description,
+		LogoURL:     b.LogoURL,
+		Country:     b.Country,
+		Website:     b.Website,
+		SocialLinks: b.SocialLinks,
+		Version:     b.Version,
+		CreatedAt:   b.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   b.UpdatedAt.Format(time.RFC3339),
+		DeletedAt:   formatDeletedAt(b.DeletedAt),
+	}
+}
+
+// ToUpdateRequest is the brand as a full update, the document a merge patch is applied to
+func (r *BrandResponse) ToUpdateRequest() UpdateBrandRequest {
+	return UpdateBrandRequest{
+		BrandName:   r.BrandName,
+		Slug:        r.Slug,
+		Description: r.Description,
+		LogoURL:     r.LogoURL,
+		Country:     r.Country,
+		Website:     r.Website,
+		SocialLinks: r.SocialLinks,
+	}
+}
+
+// AuditSnapshot is the brand as recorded in the audit log
+func (b *Brand) AuditSnapshot() AuditSnapshot {
+	return AuditSnapshot{
+		"brand_name":   b.BrandName,
+		"slug":         b.Slug,
+		"description":  b.Description,
+		"logo_url":     b.LogoURL,
+		"country":      b.Country,
+		"website":      b.Website,
+		"social_links": b.SocialLinks,
 	}
 }
 
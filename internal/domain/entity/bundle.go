@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"Unnispick/pkg/money"
+	"github.com/google/uuid"
+)
+
+const (
+	ProductTypeSimple = "simple"
+	ProductTypeBundle = "bundle"
+)
+
+type (
+	// BundleComponent is one product a bundle is made of, Quantity units per bundle sold
+	BundleComponent struct {
+		BundleID    uuid.UUID `gorm:"column:bundle_id;type:uuid;primaryKey"`
+		ComponentID uuid.UUID `gorm:"column:component_id;type:uuid;primaryKey"`
+		Quantity    int       `gorm:"type:integer;not null"`
+		Component   *Product  `gorm:"foreignKey:ComponentID"`
+	}
+
+	BundleComponentRequest struct {
+		ProductID uuid.UUID `json:"product_id" validate:"required"`
+		Quantity  int       `json:"quantity" validate:"required,gt=0"`
+	}
+
+	// CreateBundleRequest creates a product sold as a set of other products, it has no stock of its own
+	CreateBundleRequest struct {
+		ProductName string                   `json:"product_name" validate:"required,min=1,max=255"`
+		Slug        string                   `json:"slug" validate:"omitempty,max=200,slug"`
+		Price       money.Money              `json:"price" validate:"price"`
+		BrandID     uuid.UUID                `json:"brand_id" validate:"required,uuid"`
+		CategoryIDs []uuid.UUID              `json:"category_ids"`
+		Tags        []string                 `json:"tags" validate:"omitempty,max=20,dive,min=1,max=100"`
+		Components  []BundleComponentRequest `json:"components" validate:"required,min=1,max=50,dive"`
+	}
+
+	SetBundleComponentsRequest struct {
+		Components []BundleComponentRequest `json:"components" validate:"required,min=1,max=50,dive"`
+	}
+
+	BundleComponentResponse struct {
+		ProductID         uuid.UUID `json:"product_id"`
+		ProductName       string    `json:"product_name,omitempty"`
+		Quantity          int       `json:"quantity"`
+		AvailableQuantity int       `json:"available_quantity"`
+	}
+)
+
+func (*BundleComponent) TableName() string {
+	return "bundle_components"
+}
+
+func (req *CreateBundleRequest) ToProductEntity() *Product {
+	return &Product{
+		ProductName: req.ProductName,
+		Slug:        req.Slug,
+		ProductType: ProductTypeBundle,
+		Status:      ProductStatusDraft,
+		Price:       req.Price,
+		BrandID:     req.BrandID,
+		Categories:  categoriesFromIDs(req.CategoryIDs),
+		Components:  bundleComponentsFromRequest(req.Components),
+	}
+}
+
+func (req *SetBundleComponentsRequest) ToBundleComponents() []BundleComponent {
+	return bundleComponentsFromRequest(req.Components)
+}
+
+func bundleComponentsFromRequest(components []BundleComponentRequest) []BundleComponent {
+	rows := make([]BundleComponent, len(components))
+	for i, component := range components {
+		rows[i] = BundleComponent{ComponentID: component.ProductID, Quantity: component.Quantity}
+	}
+	return rows
+}
+
+// BundleComponentIDs lists the component product IDs, failing when one is listed twice
+func BundleComponentIDs(components []BundleComponent) ([]uuid.UUID, error) {
+	seen := make(map[uuid.UUID]struct{}, len(components))
+	ids := make([]uuid.UUID, len(components))
+	for i, component := range components {
+		if _, ok := seen[component.ComponentID]; ok {
+			return nil, ErrDuplicateBundleComponent
+		}
+		seen[component.ComponentID] = struct{}{}
+		ids[i] = component.ComponentID
+	}
+	return ids, nil
+}
+
+func (bc *BundleComponent) ToResponseDTO() *BundleComponentResponse {
+	response := &BundleComponentResponse{
+		ProductID: bc.ComponentID,
+		Quantity:  bc.Quantity,
+	}
+
+	if bc.Component != nil {
+		response.ProductName = bc.Component.ProductName
+		response.AvailableQuantity = bc.Component.AvailableQuantity()
+	}
+
+	return response
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+type (
+	Category struct {
+		ID           uuid.UUID  `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+		CategoryName string     `json:"category_name" validate:"required,min=1,max=255" gorm:"column:category_name;type:varchar(255);not null"`
+		ParentID     *uuid.UUID `json:"parent_id,omitempty" gorm:"column:parent_id;type:uuid"`
+		CreatedAt    time.Time  `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		UpdatedAt    time.Time  `json:"updated_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		DeletedAt    *time.Time `json:"deleted_at,omitempty" gorm:"index;type:timestamp with time zone"`
+	}
+
+	ProductCategory struct {
+		ProductID  uuid.UUID `gorm:"column:product_id;type:uuid;primaryKey"`
+		CategoryID uuid.UUID `gorm:"column:category_id;type:uuid;primaryKey"`
+	}
+
+	CategoryRepository interface {
+		Create(ctx context.Context, category *Category) error
+		GetByID(ctx context.Context, id uuid.UUID) (*Category, error)
+		GetAllWithFilter(ctx context.Context, filter CategoryFilterRepository) (categories []Category, count int64, err error)
+		Update(ctx context.Context, category *Category) error
+		Delete(ctx context.Context, id uuid.UUID) error
+		ExistsByID(ctx context.Context, id uuid.UUID) (bool, error)
+		ExistsByName(ctx context.Context, name string, parentID *uuid.UUID) (bool, error)
+		CountByIDs(ctx context.Context, ids []uuid.UUID) (int64, error)
+		GetDescendantIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	}
+
+	CategoryService interface {
+		Create(ctx context.Context, req CreateCategoryRequest) (*CategoryResponse, error)
+		GetByID(ctx context.Context, id uuid.UUID) (*CategoryResponse, error)
+		GetAll(ctx context.Context, filter CategoryFilterRequest) ([]CategoryResponse, int64, error)
+		Update(ctx context.Context, id uuid.UUID, req UpdateCategoryRequest) (*CategoryResponse, error)
+		Delete(ctx context.Context, id uuid.UUID) error
+	}
+
+	CategoryFilterRequest struct {
+		Search   string    `query:"search"`
+		ParentID uuid.UUID `query:"parent_id"`
+		RootOnly bool      `query:"root_only"`
+		Page     int       `query:"page"`
+		PerPage  int       `query:"per_page"`
+	}
+
+	CategoryFilterRepository struct {
+		Search   string
+		ParentID uuid.UUID
+		RootOnly bool
+		Limit    int
+		Offset   int
+	}
+
+	CreateCategoryRequest struct {
+		CategoryName string     `json:"category_name" validate:"required,min=1,max=255"`
+		ParentID     *uuid.UUID `json:"parent_id"`
+	}
+
+	UpdateCategoryRequest struct {
+		CategoryName string     `json:"category_name" validate:"required,min=1,max=255"`
+		ParentID     *uuid.UUID `json:"parent_id"`
+	}
+
+	CategoryResponse struct {
+		ID           uuid.UUID  `json:"id"`
+		CategoryName string     `json:"category_name"`
+		ParentID     *uuid.UUID `json:"parent_id"`
+		CreatedAt    string     `json:"created_at"`
+		UpdatedAt    string     `json:"updated_at"`
+	}
+)
+
+func (*Category) TableName() string {
+	return "categories"
+}
+
+func (*ProductCategory) TableName() string {
+	return "product_categories"
+}
+
+func (req CategoryFilterRequest) ToCategoryFilterRepo() CategoryFilterRepository {
+	return CategoryFilterRepository{
+		Search:   req.Search,
+		ParentID: req.ParentID,
+		RootOnly: req.RootOnly,
+		Limit:    req.PerPage,
+		Offset:   (req.Page - 1) * req.PerPage,
+	}
+}
+
+func (req *CreateCategoryRequest) ToCategoryEntity() *Category {
+	return &Category{
+		CategoryName: req.CategoryName,
+		ParentID:     req.ParentID,
+	}
+}
+
+func (c *Category) UpdateFromRequest(req UpdateCategoryRequest) {
+	if req.CategoryName != "" {
+		c.CategoryName = req.CategoryName
+	}
+	c.ParentID = req.ParentID
+}
+
+func (c *Category) ToResponseDTO() *CategoryResponse {
+	return &CategoryResponse{
+		ID:           c.ID,
+		CategoryName: c.CategoryName,
+		ParentID:     c.ParentID,
+		CreatedAt:    c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    c.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (req *CreateCategoryRequest) Validate() error {
+	if req.CategoryName == "" {
+		return ErrEmptyCategoryName
+	}
+	return nil
+}
+
+func (req *UpdateCategoryRequest) Validate() error {
+	if req.CategoryName == "" {
+		return ErrEmptyCategoryName
+	}
+	return nil
+}
@@ -10,4 +10,63 @@ var (
 	ErrInvalidBrandID    = errors.New("brand ID is required")
 	ErrInvalidAmount     = errors.New("amount must be greater than 0")
 	ErrInsufficientStock = errors.New("insufficient stock")
+
+	ErrEmptyCategoryName = errors.New("category name cannot be empty")
+	ErrCategoryCycle     = errors.New("category cannot be moved under itself or one of its descendants")
+	ErrEmptyTagName      = errors.New("tag name cannot be empty")
+
+	ErrEmptyIngredientName = errors.New("ingredient INCI name cannot be empty")
+	ErrDuplicateIngredient = errors.New("ingredient list cannot contain the same ingredient twice")
+
+	ErrNotBundle                = errors.New("product is not a bundle")
+	ErrBundleStockDerived       = errors.New("bundle stock is derived from its components")
+	ErrInvalidBundleComponent   = errors.New("bundle components must be existing products that are not bundles")
+	ErrDuplicateBundleComponent = errors.New("bundle cannot list the same component twice")
+	ErrProductInBundle          = errors.New("product is a component of a bundle")
+
+	ErrProductVariantNotFound = errors.New("product variant not found")
+	ErrCurrencyMismatch       = errors.New("variant prices must use the same currency as their product")
+
+	ErrUnsupportedCurrency   = errors.New("unsupported currency")
+	ErrInvalidExchangeRate   = errors.New("exchange rate must be a positive decimal")
+	ErrExchangeRateNotFound  = errors.New("no exchange rate between the requested currencies")
+	ErrPriceListItemNotFound = errors.New("product has no price in this currency")
+
+	ErrPriceScheduleNotFound = errors.New("scheduled price change not found")
+	ErrPriceScheduleInPast   = errors.New("scheduled price change must take effect in the future")
+
+	ErrPromotionNotFound      = errors.New("promotion not found")
+	ErrPromotionScopeNotFound = errors.New("promotion scope target not found")
+	ErrInvalidPercentage      = errors.New("percentage must be greater than 0 and at most 100 with at most 2 decimal places")
+	ErrInvalidAmountOff       = errors.New("fixed discount must be a positive amount in a supported currency")
+	ErrInvalidPromotionPeriod = errors.New("promotion must end after it starts")
+
+	ErrProductImageNotFound = errors.New("product image not found")
+	ErrUnsupportedImageType = errors.New("image must be a jpeg, png, webp or gif")
+	ErrImageTooLarge        = errors.New("image exceeds the maximum upload size")
+	ErrImageOrderMismatch   = errors.New("image order must list every image of the product exactly once")
+
+	ErrReservationNotFound   = errors.New("reservation not found")
+	ErrReservationNotPending = errors.New("reservation is no longer pending")
+	ErrReservationExpired    = errors.New("reservation has expired")
+	ErrReservationTTLTooLong = errors.New("reservation ttl exceeds the allowed maximum")
+
+	ErrBrandSlugTaken   = errors.New("brand slug is already in use")
+	ErrProductSlugTaken = errors.New("product slug is already in use")
+
+	ErrInvalidStatusTransition = errors.New("product cannot move to the requested status")
+
+	ErrNotInTrash          = errors.New("not found in trash")
+	ErrRestoreNameTaken    = errors.New("name is already used by another record")
+	ErrRestoreBrandTrashed = errors.New("brand of the product is in the trash, restore it first")
+
+	ErrVersionMismatch = errors.New("record was changed by another request, reload it and try again")
+
+	ErrProductNameTaken  = errors.New("product name is already in use")
+	ErrDuplicateBulkItem = errors.New("item repeats an earlier item of the same request")
+	ErrTooManyBulkItems  = errors.New("bulk request has too many items")
+
+	ErrInvalidImportFile   = errors.New("invalid import file")
+	ErrImportFileTooLarge  = errors.New("import file exceeds the maximum size")
+	ErrInvalidExportFormat = errors.New("export format must be csv or ndjson")
 )
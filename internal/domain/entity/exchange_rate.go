@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// ExchangeRate says one unit of BaseCurrency is worth Rate units of QuoteCurrency
+	ExchangeRate struct {
+		BaseCurrency  string    `json:"base_currency" gorm:"column:base_currency;type:char(3);primaryKey"`
+		QuoteCurrency string    `json:"quote_currency" gorm:"column:quote_currency;type:char(3);primaryKey"`
+		Rate          string    `json:"rate" gorm:"type:numeric(24,12);not null"`
+		CreatedAt     time.Time `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		UpdatedAt     time.Time `json:"updated_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+	}
+
+	ExchangeRateRepository interface {
+		Upsert(ctx context.Context, rates []ExchangeRate) error
+		GetAll(ctx context.Context) ([]ExchangeRate, error)
+		Get(ctx context.Context, base, quote string) (*ExchangeRate, error)
+	}
+
+	ExchangeRateService interface {
+		Upload(ctx context.Context, req UploadExchangeRatesRequest) ([]ExchangeRateResponse, error)
+		GetAll(ctx context.Context) ([]ExchangeRateResponse, error)
+	}
+
+	// UploadExchangeRatesRequest sets the rates from one base currency, e.g. {"base": "IDR", "rates": {"KRW": "0.0853"}}
+	UploadExchangeRatesRequest struct {
+		Base  string            `json:"base" validate:"required,len=3"`
+		Rates map[string]string `json:"rates" validate:"required,min=1,dive,keys,len=3,endkeys,required"`
+	}
+
+	ExchangeRateResponse struct {
+		BaseCurrency  string `json:"base_currency"`
+		QuoteCurrency string `json:"quote_currency"`
+		Rate          string `json:"rate"`
+		UpdatedAt     string `json:"updated_at"`
+	}
+)
+
+func (*ExchangeRate) TableName() string {
+	return "exchange_rates"
+}
+
+func (r *ExchangeRate) ToResponseDTO() *ExchangeRateResponse {
+	return &ExchangeRateResponse{
+		BaseCurrency:  r.BaseCurrency,
+		QuoteCurrency: r.QuoteCurrency,
+		Rate:          r.Rate,
+		UpdatedAt:     r.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,153 @@
+package entity
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+type (
+	Ingredient struct {
+		ID         uuid.UUID `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+		INCIName   string    `json:"inci_name" gorm:"column:inci_name;type:varchar(255);not null"`
+		CommonName string    `json:"common_name" gorm:"column:common_name;type:varchar(255)"`
+		CreatedAt  time.Time `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		UpdatedAt  time.Time `json:"updated_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+	}
+
+	// ProductIngredient keeps the INCI position of an ingredient in a product's ingredient list
+	ProductIngredient struct {
+		ProductID    uuid.UUID   `gorm:"column:product_id;type:uuid;primaryKey"`
+		IngredientID uuid.UUID   `gorm:"column:ingredient_id;type:uuid;primaryKey"`
+		Position     int         `gorm:"type:integer;not null"`
+		Ingredient   *Ingredient `gorm:"foreignKey:IngredientID"`
+	}
+
+	IngredientRepository interface {
+		Create(ctx context.Context, ingredient *Ingredient) error
+		GetByID(ctx context.Context, id uuid.UUID) (*Ingredient, error)
+		GetAllWithFilter(ctx context.Context, filter IngredientFilterRepository) (ingredients []Ingredient, count int64, err error)
+		Update(ctx context.Context, ingredient *Ingredient) error
+		Delete(ctx context.Context, id uuid.UUID) error
+		ExistsByName(ctx context.Context, name string) (bool, error)
+		CountByIDs(ctx context.Context, ids []uuid.UUID) (int64, error)
+	}
+
+	IngredientService interface {
+		Create(ctx context.Context, req CreateIngredientRequest) (*IngredientResponse, error)
+		GetByID(ctx context.Context, id uuid.UUID) (*IngredientResponse, error)
+		GetAll(ctx context.Context, filter IngredientFilterRequest) ([]IngredientResponse, int64, error)
+		Update(ctx context.Context, id uuid.UUID, req UpdateIngredientRequest) (*IngredientResponse, error)
+		Delete(ctx context.Context, id uuid.UUID) error
+	}
+
+	IngredientFilterRequest struct {
+		Search  string `query:"search"`
+		Page    int    `query:"page"`
+		PerPage int    `query:"per_page"`
+	}
+
+	IngredientFilterRepository struct {
+		Search string
+		Limit  int
+		Offset int
+	}
+
+	CreateIngredientRequest struct {
+		INCIName   string `json:"inci_name" validate:"required,min=1,max=255"`
+		CommonName string `json:"common_name" validate:"omitempty,max=255"`
+	}
+
+	UpdateIngredientRequest struct {
+		INCIName   string `json:"inci_name" validate:"required,min=1,max=255"`
+		CommonName string `json:"common_name" validate:"omitempty,max=255"`
+	}
+
+	// SetProductIngredientsRequest lists ingredient IDs in INCI order, highest concentration first
+	SetProductIngredientsRequest struct {
+		IngredientIDs []uuid.UUID `json:"ingredient_ids" validate:"max=200"`
+	}
+
+	IngredientResponse struct {
+		ID         uuid.UUID `json:"id"`
+		INCIName   string    `json:"inci_name"`
+		CommonName string    `json:"common_name,omitempty"`
+		CreatedAt  string    `json:"created_at"`
+		UpdatedAt  string    `json:"updated_at"`
+	}
+
+	ProductIngredientResponse struct {
+		Position   int       `json:"position"`
+		ID         uuid.UUID `json:"id"`
+		INCIName   string    `json:"inci_name"`
+		CommonName string    `json:"common_name,omitempty"`
+	}
+)
+
+func (*Ingredient) TableName() string {
+	return "ingredients"
+}
+
+func (*ProductIngredient) TableName() string {
+	return "product_ingredients"
+}
+
+func (req IngredientFilterRequest) ToIngredientFilterRepo() IngredientFilterRepository {
+	return IngredientFilterRepository{
+		Search: req.Search,
+		Limit:  req.PerPage,
+		Offset: (req.Page - 1) * req.PerPage,
+	}
+}
+
+func (req *CreateIngredientRequest) ToIngredientEntity() *Ingredient {
+	return &Ingredient{
+		INCIName:   req.INCIName,
+		CommonName: req.CommonName,
+	}
+}
+
+func (i *Ingredient) UpdateFromRequest(req UpdateIngredientRequest) {
+	if req.INCIName != "" {
+		i.INCIName = req.INCIName
+	}
+	i.CommonName = req.CommonName
+}
+
+func (i *Ingredient) ToResponseDTO() *IngredientResponse {
+	return &IngredientResponse{
+		ID:         i.ID,
+		INCIName:   i.INCIName,
+		CommonName: i.CommonName,
+		CreatedAt:  i.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  i.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (pi *ProductIngredient) ToResponseDTO() *ProductIngredientResponse {
+	response := &ProductIngredientResponse{
+		Position: pi.Position,
+		ID:       pi.IngredientID,
+	}
+
+	if pi.Ingredient != nil {
+		response.INCIName = pi.Ingredient.INCIName
+		response.CommonName = pi.Ingredient.CommonName
+	}
+
+	return response
+}
+
+func (req *CreateIngredientRequest) Validate() error {
+	if req.INCIName == "" {
+		return ErrEmptyIngredientName
+	}
+	return nil
+}
+
+func (req *UpdateIngredientRequest) Validate() error {
+	if req.INCIName == "" {
+		return ErrEmptyIngredientName
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"Unnispick/pkg/money"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+type (
+	// PriceListItem is an explicit product price in one currency, it wins over exchange rate conversion
+	PriceListItem struct {
+		ProductID uuid.UUID   `json:"product_id" gorm:"column:product_id;type:uuid;primaryKey"`
+		Price     money.Money `json:"price" gorm:"embedded"`
+		CreatedAt time.Time   `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		UpdatedAt time.Time   `json:"updated_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+	}
+
+	PriceListRepository interface {
+		Upsert(ctx context.Context, item *PriceListItem) error
+		GetAllByProductID(ctx context.Context, productID uuid.UUID) ([]PriceListItem, error)
+		GetByProductIDs(ctx context.Context, productIDs []uuid.UUID, currency string) ([]PriceListItem, error)
+		Delete(ctx context.Context, productID uuid.UUID, currency string) error
+	}
+
+	PriceListService interface {
+		SetPrice(ctx context.Context, productID uuid.UUID, req SetPriceListItemRequest) (*PriceListItemResponse, error)
+		GetAll(ctx context.Context, productID uuid.UUID) ([]PriceListItemResponse, error)
+		Delete(ctx context.Context, productID uuid.UUID, currency string) error
+		ApplyCurrency(ctx context.Context, currency string, products ...*ProductResponse) error
+	}
+
+	SetPriceListItemRequest struct {
+		Price money.Money `json:"price" validate:"price"`
+	}
+
+	PriceListItemResponse struct {
+		ProductID uuid.UUID   `json:"product_id"`
+		Price     money.Money `json:"price"`
+		UpdatedAt string      `json:"updated_at"`
+	}
+)
+
+func (*PriceListItem) TableName() string {
+	return "price_list_items"
+}
+
+func (i *PriceListItem) ToResponseDTO() *PriceListItemResponse {
+	return &PriceListItemResponse{
+		ProductID: i.ProductID,
+		Price:     i.Price,
+		UpdatedAt: i.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -1,86 +1,207 @@
 package entity
 
 import (
+	"Unnispick/pkg/money"
 	"context"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type (
 	Product struct {
-		ID          uuid.UUID  `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
-		ProductName string     `json:"product_name" validate:"required,min=1,max=255" gorm:"column:product_name;type:varchar(255);not null"`
-		Price       float64    `json:"price" validate:"required,gt=0" gorm:"type:decimal(15,2);not null;check:price > 0"`
-		Quantity    int        `json:"quantity" validate:"required,gte=0" gorm:"type:integer;not null;check:quantity >= 0"`
-		BrandID     uuid.UUID  `json:"brand_id" validate:"required,uuid" gorm:"column:brand_id;type:uuid;not null"`
-		Brand       *Brand     `json:"brand,omitempty" gorm:"foreignKey:BrandID"`
-		CreatedAt   time.Time  `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
-		UpdatedAt   time.Time  `json:"updated_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
-		DeletedAt   *time.Time `json:"deleted_at,omitempty" gorm:"index;type:timestamp with time zone"`
+		ID               uuid.UUID           `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+		ProductName      string              `json:"product_name" validate:"required,min=1,max=255" gorm:"column:product_name;type:varchar(255);not null"`
+		Slug             string              `json:"slug" gorm:"type:varchar(255);not null;uniqueIndex"`
+		ProductType      string              `json:"product_type" gorm:"column:product_type;type:varchar(20);not null;default:simple"`
+		Status           string              `json:"status" gorm:"type:varchar(20);not null;default:draft;index"`
+		Price            money.Money         `json:"price" validate:"price" gorm:"embedded;embeddedPrefix:price_"`
+		Quantity         int                 `json:"quantity" validate:"required,gte=0" gorm:"type:integer;not null;check:quantity >= 0"`
+		ReservedQuantity int                 `json:"reserved_quantity" gorm:"->;-:migration"`
+		BrandID          uuid.UUID           `json:"brand_id" validate:"required,uuid" gorm:"column:brand_id;type:uuid;not null"`
+		Brand            *Brand              `json:"brand,omitempty" gorm:"foreignKey:BrandID"`
+		Variants         []ProductVariant    `json:"variants,omitempty" gorm:"foreignKey:ProductID"`
+		Categories       []Category          `json:"categories,omitempty" gorm:"many2many:product_categories"`
+		Tags             []Tag               `json:"tags,omitempty" gorm:"many2many:product_tags"`
+		Ingredients      []ProductIngredient `json:"ingredients,omitempty" gorm:"foreignKey:ProductID"`
+		Images           []ProductImage      `json:"images,omitempty" gorm:"foreignKey:ProductID"`
+		Components       []BundleComponent   `json:"components,omitempty" gorm:"foreignKey:BundleID"`
+		Version          int                 `json:"version" gorm:"type:integer;not null;default:1"`
+		CreatedAt        time.Time           `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		UpdatedAt        time.Time           `json:"updated_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		DeletedAt        gorm.DeletedAt      `json:"deleted_at,omitempty" gorm:"index;type:timestamp with time zone"`
+
+		// Filled by a text search with the matched words of each field marked, empty otherwise
+		HighlightedName        string `json:"-" gorm:"->;-:migration"`
+		HighlightedBrandName   string `json:"-" gorm:"->;-:migration"`
+		HighlightedDescription string `json:"-" gorm:"->;-:migration"`
 	}
 
 	ProductRepository interface {
 		Create(ctx context.Context, product *Product) error
 		GetByID(ctx context.Context, id uuid.UUID) (*Product, error)
 		GetAllWithFilter(ctx context.Context, filter ProductFilterRepository) (products []Product, count int64, err error)
+		// Update writes the product and replaces its categories and tags with the ones it carries
 		Update(ctx context.Context, product *Product) error
-		Delete(ctx context.Context, id uuid.UUID) error
+		Delete(ctx context.Context, id uuid.UUID, version int) error
 		ExistsByID(ctx context.Context, id uuid.UUID) (bool, error)
 		GetByName(ctx context.Context, name string) (*Product, error)
 		ExistsByName(ctx context.Context, name string) (bool, error)
+		GetBySlug(ctx context.Context, slug string) (*Product, error)
+		IsSlugTaken(ctx context.Context, slug string, exceptID uuid.UUID) (bool, error)
+		ReplaceIngredients(ctx context.Context, id uuid.UUID, ingredientIDs []uuid.UUID) error
+		ReplaceBundleComponents(ctx context.Context, id uuid.UUID, components []BundleComponent) error
+		CountSimpleByIDs(ctx context.Context, ids []uuid.UUID) (int64, error)
+		IsBundleComponent(ctx context.Context, id uuid.UUID) (bool, error)
+		// IncreaseStock and DecreaseStock return the quantity the product is left with. Selling a
+		// bundle takes its components out of stock and leaves the bundle at the zero it holds.
+		IncreaseStock(ctx context.Context, id uuid.UUID, amount int, note StockMovementNote) (int, error)
+		DecreaseStock(ctx context.Context, id uuid.UUID, amount int, note StockMovementNote) (int, error)
+		UpdateStatus(ctx context.Context, id uuid.UUID, from, to string) error
+		GetTrashedByID(ctx context.Context, id uuid.UUID) (*Product, error)
+		GetAllTrashed(ctx context.Context, filter TrashFilterRepository) (products []Product, count int64, err error)
+		Restore(ctx context.Context, id uuid.UUID) error
+		// Purge also returns the storage keys of the images that went with the purged products,
+		// the stored files are for the caller to remove once the rows are gone
+		Purge(ctx context.Context, deletedBefore time.Time) (purged int64, imageKeys []string, err error)
+		GetByIDs(ctx context.Context, ids []uuid.UUID) ([]Product, error)
+		ExistingNames(ctx context.Context, names []string) ([]string, error)
+		TakenSlugs(ctx context.Context, slugs []string) ([]string, error)
+		// FilterBundleComponents returns the ones of ids that are a component of some bundle
+		FilterBundleComponents(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+		// GetVersionsByNames loads only the ID, name and version of the products with the given names
+		GetVersionsByNames(ctx context.Context, names []string) ([]Product, error)
+		// CreateBatch, UpdateBatch and DeleteBatch write every product in one transaction. UpdateBatch
+		// replaces the categories and tags of each product with the ones it carries.
+		CreateBatch(ctx context.Context, products []*Product) error
+		UpdateBatch(ctx context.Context, products []*Product) error
+		DeleteBatch(ctx context.Context, products []*Product) error
+		// StreamWithFilter hands every product matching the filter to fn as it is read, stopping at
+		// the first error fn returns
+		StreamWithFilter(ctx context.Context, filter ProductFilterRepository, fn func(row *ProductExportRow) error) error
 	}
 
 	ProductService interface {
 		Create(ctx context.Context, req CreateProductRequest) (*ProductResponse, error)
 		GetByID(ctx context.Context, id uuid.UUID) (*ProductResponse, error)
+		GetBySlug(ctx context.Context, slug string) (*ProductResponse, error)
 		GetAll(ctx context.Context, filter ProductFilterRequest) ([]ProductResponse, int64, error)
-		Update(ctx context.Context, id uuid.UUID, req UpdateProductRequest) (*ProductResponse, error)
-		Delete(ctx context.Context, id uuid.UUID) error
+		Update(ctx context.Context, id uuid.UUID, version int, req UpdateProductRequest) (*ProductResponse, error)
+		Delete(ctx context.Context, id uuid.UUID, version int) error
+		IncreaseStock(ctx context.Context, id uuid.UUID, req StockAdjustmentRequest) (*ProductResponse, error)
+		DecreaseStock(ctx context.Context, id uuid.UUID, req StockAdjustmentRequest) (*ProductResponse, error)
+		GetStockMovements(ctx context.Context, id uuid.UUID, filter StockMovementFilterRequest) ([]StockMovementResponse, int64, error)
+		SetIngredients(ctx context.Context, id uuid.UUID, req SetProductIngredientsRequest) (*ProductResponse, error)
+		CreateBundle(ctx context.Context, req CreateBundleRequest) (*ProductResponse, error)
+		SetBundleComponents(ctx context.Context, id uuid.UUID, req SetBundleComponentsRequest) (*ProductResponse, error)
+		Publish(ctx context.Context, id uuid.UUID) (*ProductResponse, error)
+		Discontinue(ctx context.Context, id uuid.UUID) (*ProductResponse, error)
+		Archive(ctx context.Context, id uuid.UUID) (*ProductResponse, error)
+		GetTrash(ctx context.Context, filter TrashFilterRequest) ([]ProductResponse, int64, error)
+		Restore(ctx context.Context, id uuid.UUID) (*ProductResponse, error)
+		// The bulk operations skip the items rejected maps to validation errors
+		BulkCreate(ctx context.Context, req BulkCreateProductsRequest, rejected map[int][]string) (*BulkResponse, error)
+		BulkUpdate(ctx context.Context, req BulkUpdateProductsRequest, rejected map[int][]string) (*BulkResponse, error)
+		BulkDelete(ctx context.Context, req BulkDeleteProductsRequest, rejected map[int][]string) (*BulkResponse, error)
 	}
 
 	ProductFilterRequest struct {
-		BrandID  uuid.UUID `query:"brand_id"`
-		MinPrice float64   `query:"min_price"`
-		MaxPrice float64   `query:"max_price"`
-		MinQty   int       `query:"min_qty"`
-		MaxQty   int       `query:"max_qty"`
-		Page     int       `query:"page"`
-		PerPage  int       `query:"per_page"`
+		Query              string      `query:"q"`
+		BrandID            uuid.UUID   `query:"brand_id"`
+		CategoryID         uuid.UUID   `query:"category_id"`
+		IncludeDescendants bool        `query:"include_descendants"`
+		Tags               []string    `query:"tags"`
+		TagMode            string      `query:"tag_mode"`
+		IncludeIngredients []string    `query:"include_ingredients"`
+		ExcludeIngredients []string    `query:"exclude_ingredients"`
+		MinPrice           money.Money `query:"min_price"`
+		MaxPrice           money.Money `query:"max_price"`
+		MinQty             int         `query:"min_qty"`
+		MaxQty             int         `query:"max_qty"`
+		ProductType        string      `query:"product_type"`
+		Status             string      `query:"status"`
+		Page               int         `query:"page"`
+		PerPage            int         `query:"per_page"`
 	}
 
 	ProductFilterRepository struct {
-		BrandID  uuid.UUID
-		MinPrice float64
-		MaxPrice float64
-		MinQty   int
-		MaxQty   int
-		Limit    int
-		Offset   int
+		Query              string
+		BrandID            uuid.UUID
+		CategoryID         uuid.UUID
+		IncludeDescendants bool
+		Tags               []string
+		TagMode            string
+		IncludeIngredients []string
+		ExcludeIngredients []string
+		MinPrice           money.Money
+		MaxPrice           money.Money
+		MinQty             int
+		MaxQty             int
+		ProductType        string
+		Status             string
+		Limit              int
+		Offset             int
 	}
 
 	CreateProductRequest struct {
-		ProductName string    `json:"product_name" validate:"required,min=1,max=255"`
-		Price       float64   `json:"price" validate:"required,gt=0"`
-		Quantity    int       `json:"quantity" validate:"required,gte=0"`
-		BrandID     uuid.UUID `json:"brand_id" validate:"required,uuid"`
+		ProductName string      `json:"product_name" validate:"required,min=1,max=255"`
+		Slug        string      `json:"slug" validate:"omitempty,max=200,slug"`
+		Price       money.Money `json:"price" validate:"price"`
+		Quantity    int         `json:"quantity" validate:"required,gte=0"`
+		BrandID     uuid.UUID   `json:"brand_id" validate:"required,uuid"`
+		CategoryIDs []uuid.UUID `json:"category_ids"`
+		Tags        []string    `json:"tags" validate:"omitempty,max=20,dive,min=1,max=100"`
 	}
 
+	// UpdateProductRequest is also the document a merge patch is applied to, a patch rule replaces
+	// the validate rule of a field the patch sets
 	UpdateProductRequest struct {
-		ProductName string    `json:"product_name" validate:"required,min=1,max=255"`
-		Price       float64   `json:"price" validate:"required,gt=0"`
-		Quantity    int       `json:"quantity" validate:"required,gte=0"`
-		BrandID     uuid.UUID `json:"brand_id" validate:"required,uuid"`
+		ProductName string      `json:"product_name" validate:"required,min=1,max=255"`
+		Slug        string      `json:"slug" validate:"omitempty,max=200,slug"`
+		Price       money.Money `json:"price" validate:"price"`
+		Quantity    int         `json:"quantity" validate:"required,gte=0" patch:"gte=0"`
+		BrandID     uuid.UUID   `json:"brand_id" validate:"required,uuid"`
+		CategoryIDs []uuid.UUID `json:"category_ids"`
+		Tags        []string    `json:"tags" validate:"omitempty,max=20,dive,min=1,max=100"`
+	}
+
+	StockAdjustmentRequest struct {
+		Amount    int    `json:"amount" validate:"required,gt=0"`
+		Reason    string `json:"reason" validate:"omitempty,max=50"`
+		Reference string `json:"reference" validate:"omitempty,max=255"`
 	}
 
 	ProductResponse struct {
-		ID          uuid.UUID      `json:"id"`
-		ProductName string         `json:"product_name"`
-		Price       float64        `json:"price"`
-		Quantity    int            `json:"quantity"`
-		BrandID     uuid.UUID      `json:"brand_id"`
-		Brand       *BrandResponse `json:"brand,omitempty"`
-		CreatedAt   string         `json:"created_at"`
-		UpdatedAt   string         `json:"updated_at"`
+		ID                uuid.UUID                   `json:"id"`
+		ProductName       string                      `json:"product_name"`
+		Slug              string                      `json:"slug"`
+		ProductType       string                      `json:"product_type"`
+		Status            string                      `json:"status"`
+		Price             money.Money                 `json:"price"`
+		FinalPrice        money.Money                 `json:"final_price"`
+		AppliedPromotions []AppliedPromotionResponse  `json:"applied_promotions,omitempty"`
+		Quantity          int                         `json:"quantity"`
+		ReservedQuantity  int                         `json:"reserved_quantity"`
+		AvailableQuantity int                         `json:"available_quantity"`
+		BrandID           uuid.UUID                   `json:"brand_id"`
+		Brand             *BrandResponse              `json:"brand,omitempty"`
+		Variants          []ProductVariantResponse    `json:"variants,omitempty"`
+		Categories        []CategoryResponse          `json:"categories,omitempty"`
+		Tags              []string                    `json:"tags,omitempty"`
+		Ingredients       []ProductIngredientResponse `json:"ingredients,omitempty"`
+		Images            []ProductImageResponse      `json:"images,omitempty"`
+		Components        []BundleComponentResponse   `json:"components,omitempty"`
+		Highlights        map[string]string           `json:"highlights,omitempty"`
+		PriceRange        PriceRange                  `json:"price_range"`
+		TotalStock        int                         `json:"total_stock"`
+		Version           int                         `json:"version"`
+		CreatedAt         string                      `json:"created_at"`
+		UpdatedAt         string                      `json:"updated_at"`
+		DeletedAt         string                      `json:"deleted_at,omitempty"`
 	}
 )
 
@@ -89,34 +210,141 @@ func (*Product) TableName() string {
 }
 
 func (req ProductFilterRequest) ToProductFilterRepo() ProductFilterRepository {
+	// Only live products are listed unless a status is asked for, "all" lifts the filter
+	status := req.Status
+	switch status {
+	case "":
+		status = ProductStatusActive
+	case ProductStatusAll:
+		status = ""
+	}
+
 	return ProductFilterRepository{
-		BrandID:  req.BrandID,
-		MinPrice: req.MinPrice,
-		MaxPrice: req.MaxPrice,
-		MinQty:   req.MinQty,
-		MaxQty:   req.MaxQty,
-		Limit:    req.PerPage,
-		Offset:   (req.Page - 1) * req.PerPage,
+		Query:              req.Query,
+		BrandID:            req.BrandID,
+		CategoryID:         req.CategoryID,
+		IncludeDescendants: req.IncludeDescendants,
+		Tags:               req.Tags,
+		TagMode:            req.TagMode,
+		IncludeIngredients: req.IncludeIngredients,
+		ExcludeIngredients: req.ExcludeIngredients,
+		MinPrice:           req.MinPrice,
+		MaxPrice:           req.MaxPrice,
+		MinQty:             req.MinQty,
+		MaxQty:             req.MaxQty,
+		ProductType:        req.ProductType,
+		Status:             status,
+		Limit:              req.PerPage,
+		Offset:             (req.Page - 1) * req.PerPage,
+	}
+}
+
+// ParseProductFilter reads the list filters of a query string, the same for the list endpoint, the
+// export and the export command. A value that does not parse is ignored.
+func ParseProductFilter(query url.Values) ProductFilterRequest {
+	filter := ProductFilterRequest{Query: strings.TrimSpace(query.Get("q"))}
+
+	if brandID := query.Get("brand_id"); brandID != "" {
+		if id, err := uuid.Parse(brandID); err == nil {
+			filter.BrandID = id
+		}
+	}
+	if categoryID := query.Get("category_id"); categoryID != "" {
+		if id, err := uuid.Parse(categoryID); err == nil {
+			filter.CategoryID = id
+		}
+	}
+	if includeDescendants, err := strconv.ParseBool(query.Get("include_descendants")); err == nil {
+		filter.IncludeDescendants = includeDescendants
+	}
+	if tags := query.Get("tags"); tags != "" {
+		filter.Tags = NormalizeTagNames(strings.Split(tags, ","))
+		filter.TagMode = strings.ToLower(query.Get("tag_mode"))
+	}
+	if include := query.Get("include_ingredients"); include != "" {
+		filter.IncludeIngredients = splitFilterList(include)
+	}
+	if exclude := query.Get("exclude_ingredients"); exclude != "" {
+		filter.ExcludeIngredients = splitFilterList(exclude)
+	}
+	if minPrice := query.Get("min_price"); minPrice != "" {
+		if price, err := money.Parse(minPrice, money.DefaultCurrency); err == nil {
+			filter.MinPrice = price
+		}
+	}
+	if maxPrice := query.Get("max_price"); maxPrice != "" {
+		if price, err := money.Parse(maxPrice, money.DefaultCurrency); err == nil {
+			filter.MaxPrice = price
+		}
+	}
+	if minQty := query.Get("min_qty"); minQty != "" {
+		if qty, err := strconv.Atoi(minQty); err == nil {
+			filter.MinQty = qty
+		}
+	}
+	if maxQty := query.Get("max_qty"); maxQty != "" {
+		if qty, err := strconv.Atoi(maxQty); err == nil {
+			filter.MaxQty = qty
+		}
+	}
+	if productType := strings.ToLower(query.Get("product_type")); productType == ProductTypeSimple || productType == ProductTypeBundle {
+		filter.ProductType = productType
+	}
+	if status := strings.ToLower(query.Get("status")); IsProductStatus(status) || status == ProductStatusAll {
+		filter.Status = status
+	}
+
+	return filter
+}
+
+// splitFilterList turns a comma separated query value into its trimmed, non-empty parts
+func splitFilterList(value string) []string {
+	var parts []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
 	}
+	return parts
 }
 
 func (req *CreateProductRequest) ToProductEntity() *Product {
 	return &Product{
 		ProductName: req.ProductName,
+		Slug:        req.Slug,
+		ProductType: ProductTypeSimple,
+		Status:      ProductStatusDraft,
 		Price:       req.Price,
 		Quantity:    req.Quantity,
 		BrandID:     req.BrandID,
+		Categories:  categoriesFromIDs(req.CategoryIDs),
+	}
+}
+
+func categoriesFromIDs(ids []uuid.UUID) []Category {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	categories := make([]Category, len(ids))
+	for i, id := range ids {
+		categories[i] = Category{ID: id}
 	}
+	return categories
 }
 
 func (p *Product) UpdateFromRequest(req UpdateProductRequest) {
 	if req.ProductName != "" {
 		p.ProductName = req.ProductName
 	}
-	if req.Price > 0 {
+	if req.Slug != "" {
+		p.Slug = req.Slug
+	}
+	if req.Price.IsPositive() {
 		p.Price = req.Price
 	}
-	if req.Quantity >= 0 {
+	// A bundle keeps no stock of its own
+	if req.Quantity >= 0 && !p.IsBundle() {
 		p.Quantity = req.Quantity
 	}
 	if req.BrandID != uuid.Nil {
@@ -124,18 +352,221 @@ func (p *Product) UpdateFromRequest(req UpdateProductRequest) {
 	}
 }
 
+// IsBundle reports whether the product is sold as a set of other products
+func (p *Product) IsBundle() bool {
+	return p.ProductType == ProductTypeBundle
+}
+
+// AvailableQuantity is the stock not held by reservations, for a bundle the number of complete
+// bundles the available component stock can make
+func (p *Product) AvailableQuantity() int {
+	if p.IsBundle() {
+		return p.bundleQuantity((*Product).AvailableQuantity)
+	}
+
+	available := p.Quantity - p.ReservedQuantity
+	if available < 0 {
+		return 0
+	}
+	return available
+}
+
+// PriceRange spans the variant prices, or the product price when it has no variants
+func (p *Product) PriceRange() PriceRange {
+	if len(p.Variants) == 0 {
+		return PriceRange{Min: p.Price, Max: p.Price}
+	}
+
+	priceRange := PriceRange{Min: p.Variants[0].Price, Max: p.Variants[0].Price}
+	for _, variant := range p.Variants[1:] {
+		if variant.Price.Amount < priceRange.Min.Amount {
+			priceRange.Min = variant.Price
+		}
+		if variant.Price.Amount > priceRange.Max.Amount {
+			priceRange.Max = variant.Price
+		}
+	}
+	return priceRange
+}
+
+// TotalStock sums the variant quantities, or returns the product quantity when it has no variants
+func (p *Product) TotalStock() int {
+	if p.IsBundle() {
+		return p.bundleQuantity(func(component *Product) int { return component.Quantity })
+	}
+	if len(p.Variants) == 0 {
+		return p.Quantity
+	}
+
+	total := 0
+	for _, variant := range p.Variants {
+		total += variant.Quantity
+	}
+	return total
+}
+
+// bundleQuantity is the smallest number of bundles any component can supply, given the units of
+// each component product
+func (p *Product) bundleQuantity(units func(component *Product) int) int {
+	if len(p.Components) == 0 {
+		return 0
+	}
+
+	quantity := -1
+	for _, component := range p.Components {
+		if component.Component == nil || component.Quantity <= 0 {
+			return 0
+		}
+		if covered := units(component.Component) / component.Quantity; quantity < 0 || covered < quantity {
+			quantity = covered
+		}
+	}
+	return quantity
+}
+
+// SearchHighlights returns the fields a text search matched on, keyed by field name. Each is safe
+// HTML, the text escaped and the matched words wrapped in SearchHighlightStart and
+// SearchHighlightStop.
+func (p *Product) SearchHighlights() map[string]string {
+	highlights := map[string]string{}
+	for field, text := range map[string]string{
+		"product_name":      p.HighlightedName,
+		"brand_name":        p.HighlightedBrandName,
+		"brand_description": p.HighlightedDescription,
+	} {
+		if strings.Contains(text, SearchMatchStart) {
+			highlights[field] = HighlightHTML(text)
+		}
+	}
+	if len(highlights) == 0 {
+		return nil
+	}
+	return highlights
+}
+
 func (p *Product) ToResponseDTO() *ProductResponse {
 	response := &ProductResponse{
-		ID:          p.ID,
-		ProductName: p.ProductName,
-		Price:       p.Price,
-		Quantity:    p.Quantity,
-		BrandID:     p.BrandID,
+		ID:                p.ID,
+		ProductName:       p.ProductName,
+		Slug:              p.Slug,
+		ProductType:       p.ProductType,
+		Status:            p.Status,
+		Price:             p.Price,
+		FinalPrice:        p.Price,
+		Quantity:          p.Quantity,
+		ReservedQuantity:  p.ReservedQuantity,
+		AvailableQuantity: p.AvailableQuantity(),
+		PriceRange:        p.PriceRange(),
+		TotalStock:        p.TotalStock(),
+		BrandID:           p.BrandID,
+		Version:           p.Version,
+		DeletedAt:         formatDeletedAt(p.DeletedAt),
 	}
 
 	if p.Brand != nil {
 		response.Brand = p.Brand.ToResponseDTO()
 	}
 
+	for _, variant := range p.Variants {
+		response.Variants = append(response.Variants, *variant.ToResponseDTO())
+	}
+
+	for _, category := range p.Categories {
+		response.Categories = append(response.Categories, *category.ToResponseDTO())
+	}
+
+	for _, tag := range p.Tags {
+		response.Tags = append(response.Tags, tag.TagName)
+	}
+
+	for _, ingredient := range p.Ingredients {
+		response.Ingredients = append(response.Ingredients, *ingredient.ToResponseDTO())
+	}
+
+	for _, image := range p.Images {
+		response.Images = append(response.Images, *image.ToResponseDTO())
+	}
+
+	for _, component := range p.Components {
+		response.Components = append(response.Components, *component.ToResponseDTO())
+	}
+
 	return response
 }
+
+// ToUpdateRequest is the product as a full update, the document a merge patch is applied to
+func (r *ProductResponse) ToUpdateRequest() UpdateProductRequest {
+	categoryIDs := make([]uuid.UUID, len(r.Categories))
+	for i, category := range r.Categories {
+		categoryIDs[i] = category.ID
+	}
+
+	return UpdateProductRequest{
+		ProductName: r.ProductName,
+		Slug:        r.Slug,
+		Price:       r.Price,
+		Quantity:    r.Quantity,
+		BrandID:     r.BrandID,
+		CategoryIDs: categoryIDs,
+		Tags:        append([]string{}, r.Tags...),
+	}
+}
+
+// AuditSnapshot is the product as recorded in the audit log, including its associations
+func (p *Product) AuditSnapshot() AuditSnapshot {
+	categoryIDs := make([]uuid.UUID, len(p.Categories))
+	for i, category := range p.Categories {
+		categoryIDs[i] = category.ID
+	}
+	// Associations without a meaningful order are sorted so reloading does not show up as a change
+	sort.Slice(categoryIDs, func(i, j int) bool { return categoryIDs[i].String() < categoryIDs[j].String() })
+
+	tags := make([]string, len(p.Tags))
+	for i, tag := range p.Tags {
+		tags[i] = tag.TagName
+	}
+	sort.Strings(tags)
+
+	ingredientIDs := make([]uuid.UUID, len(p.Ingredients))
+	for i, ingredient := range p.Ingredients {
+		ingredientIDs[i] = ingredient.IngredientID
+	}
+
+	components := make([]BundleComponentRequest, len(p.Components))
+	for i, component := range p.Components {
+		components[i] = BundleComponentRequest{ProductID: component.ComponentID, Quantity: component.Quantity}
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i].ProductID.String() < components[j].ProductID.String() })
+
+	return AuditSnapshot{
+		"product_name":   p.ProductName,
+		"slug":           p.Slug,
+		"product_type":   p.ProductType,
+		"status":         p.Status,
+		"price":          p.Price,
+		"quantity":       p.Quantity,
+		"brand_id":       p.BrandID,
+		"category_ids":   categoryIDs,
+		"tags":           tags,
+		"ingredient_ids": ingredientIDs,
+		"components":     components,
+	}
+}
+
+func (req *StockAdjustmentRequest) Validate() error {
+	if req.Amount <= 0 {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
+func (req *StockAdjustmentRequest) ToStockMovementNote(defaultReason string) StockMovementNote {
+	reason := req.Reason
+	if reason == "" {
+		reason = defaultReason
+	}
+	return StockMovementNote{
+		Reason:    reason,
+		Reference: req.Reference,
+	}
+}
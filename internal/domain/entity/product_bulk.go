@@ -0,0 +1,171 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+// MaxBulkItems caps the items of one bulk request so it fits in a single transaction
+const MaxBulkItems = 500
+
+// Outcome of one item of a bulk request
+const (
+	BulkStatusCreated = "created"
+	BulkStatusUpdated = "updated"
+	BulkStatusDeleted = "deleted"
+	// BulkStatusValid marks an item that passed every check of a dry run
+	BulkStatusValid           = "valid"
+	BulkStatusInvalid         = "invalid"
+	BulkStatusNotFound        = "not_found"
+	BulkStatusConflict        = "conflict"
+	BulkStatusVersionMismatch = "version_mismatch"
+	// BulkStatusNotApplied marks a valid item left out because another item failed in atomic mode
+	BulkStatusNotApplied = "not_applied"
+	BulkStatusFailed     = "failed"
+)
+
+type (
+	BulkCreateProductsRequest struct {
+		Items []CreateProductRequest `json:"items" validate:"required,min=1"`
+		// Atomic writes nothing unless every item succeeds
+		Atomic bool `json:"-"`
+		// DryRun checks every item without writing, PlannedBrandIDs are brands the caller creates
+		// before the real run and the checks take as existing
+		DryRun          bool        `json:"-"`
+		PlannedBrandIDs []uuid.UUID `json:"-"`
+	}
+
+	// BulkUpdateProductRequest is a full product update addressed by ID and the version it was read at
+	BulkUpdateProductRequest struct {
+		ID      uuid.UUID `json:"id" validate:"required"`
+		Version int       `json:"version" validate:"required,gte=1"`
+		UpdateProductRequest
+	}
+
+	BulkUpdateProductsRequest struct {
+		Items           []BulkUpdateProductRequest `json:"items" validate:"required,min=1"`
+		Atomic          bool                       `json:"-"`
+		DryRun          bool                       `json:"-"`
+		PlannedBrandIDs []uuid.UUID                `json:"-"`
+	}
+
+	BulkDeleteProductRequest struct {
+		ID      uuid.UUID `json:"id" validate:"required"`
+		Version int       `json:"version" validate:"required,gte=1"`
+	}
+
+	BulkDeleteProductsRequest struct {
+		Items  []BulkDeleteProductRequest `json:"items" validate:"required,min=1"`
+		Atomic bool                       `json:"-"`
+	}
+
+	// BulkItemResult is the outcome of the item at Index in the request
+	BulkItemResult struct {
+		Index  int        `json:"index"`
+		Status string     `json:"status"`
+		ID     *uuid.UUID `json:"id,omitempty"`
+		Errors []string   `json:"errors,omitempty"`
+	}
+
+	BulkResponse struct {
+		Atomic    bool             `json:"atomic"`
+		Succeeded int              `json:"succeeded"`
+		Failed    int              `json:"failed"`
+		Results   []BulkItemResult `json:"results"`
+	}
+)
+
+// NewBulkResponse starts a response for count items, rejected maps the index of items that failed
+// validation to their messages
+func NewBulkResponse(count int, atomic bool, rejected map[int][]string) *BulkResponse {
+	response := &BulkResponse{
+		Atomic:  atomic,
+		Results: make([]BulkItemResult, count),
+	}
+	for i := range response.Results {
+		response.Results[i].Index = i
+		if messages, ok := rejected[i]; ok {
+			response.Results[i].Status = BulkStatusInvalid
+			response.Results[i].Errors = messages
+		}
+	}
+	return response
+}
+
+// Pending reports whether the item has no outcome yet
+func (r *BulkResponse) Pending(index int) bool {
+	return r.Results[index].Status == ""
+}
+
+// HasFailures reports whether any item has failed so far
+func (r *BulkResponse) HasFailures() bool {
+	for _, result := range r.Results {
+		if result.Status != "" && !bulkSucceeded(result.Status) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *BulkResponse) Succeed(index int, status string, id uuid.UUID) {
+	r.Results[index].Status = status
+	r.Results[index].ID = &id
+}
+
+func (r *BulkResponse) Fail(index int, status string, err error) {
+	r.Results[index].Status = status
+	r.Results[index].Errors = []string{err.Error()}
+}
+
+// Validate marks every item still pending as valid, for a dry run
+func (r *BulkResponse) Validate() {
+	for i := range r.Results {
+		if r.Results[i].Status == "" {
+			r.Results[i].Status = BulkStatusValid
+		}
+	}
+}
+
+// Abandon marks every item that has not failed as not applied, for an atomic request with a failure
+func (r *BulkResponse) Abandon() {
+	for i := range r.Results {
+		if r.Results[i].Status == "" || bulkSucceeded(r.Results[i].Status) {
+			r.Results[i].Status = BulkStatusNotApplied
+			r.Results[i].ID = nil
+		}
+	}
+}
+
+// Tally counts the outcomes once every item has one
+func (r *BulkResponse) Tally() {
+	r.Succeeded, r.Failed = 0, 0
+	for _, result := range r.Results {
+		if bulkSucceeded(result.Status) {
+			r.Succeeded++
+		} else {
+			r.Failed++
+		}
+	}
+}
+
+func bulkSucceeded(status string) bool {
+	switch status {
+	case BulkStatusCreated, BulkStatusUpdated, BulkStatusDeleted, BulkStatusValid:
+		return true
+	default:
+		return false
+	}
+}
+
+// BatchItemError ties a failed batch write to the position of the product that caused it
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchItemError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"Unnispick/pkg/money"
+	"context"
+	"github.com/google/uuid"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats a catalog export is written in
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// Columns of a CSV export that an import does not read. An import skips them, so an exported file
+// can be imported again.
+const (
+	ExportColumnID          = "id"
+	ExportColumnProductType = "product_type"
+	ExportColumnStatus      = "status"
+	ExportColumnBrandID     = "brand_id"
+	ExportColumnVersion     = "version"
+	ExportColumnCreatedAt   = "created_at"
+	ExportColumnUpdatedAt   = "updated_at"
+)
+
+// ProductExportColumns is the header row of a CSV export. The columns an import reads carry the
+// same names, list cells separate their values with ImportListSeparator.
+var ProductExportColumns = []string{
+	ExportColumnID,
+	ImportColumnProductName,
+	ImportColumnSlug,
+	ExportColumnProductType,
+	ExportColumnStatus,
+	ImportColumnPrice,
+	ImportColumnCurrency,
+	ImportColumnQuantity,
+	ExportColumnBrandID,
+	ImportColumnBrandName,
+	ImportColumnCategoryIDs,
+	ImportColumnTags,
+	ExportColumnVersion,
+	ExportColumnCreatedAt,
+	ExportColumnUpdatedAt,
+}
+
+type (
+	ProductExportService interface {
+		// Export writes every product matching the filter to w and returns how many it wrote
+		Export(ctx context.Context, filter ProductFilterRequest, format string, w io.Writer) (int, error)
+	}
+
+	// ProductExportRow is one product of an export, flattened to the columns of a catalog file
+	ProductExportRow struct {
+		ID          uuid.UUID   `json:"id"`
+		ProductName string      `json:"product_name"`
+		Slug        string      `json:"slug"`
+		ProductType string      `json:"product_type"`
+		Status      string      `json:"status"`
+		Price       money.Money `json:"price"`
+		Quantity    int         `json:"quantity"`
+		BrandID     uuid.UUID   `json:"brand_id"`
+		BrandName   string      `json:"brand_name"`
+		CategoryIDs []uuid.UUID `json:"category_ids"`
+		Tags        []string    `json:"tags"`
+		Version     int         `json:"version"`
+		CreatedAt   time.Time   `json:"created_at"`
+		UpdatedAt   time.Time   `json:"updated_at"`
+	}
+)
+
+// IsExportFormat reports whether the format is one an export can be written in
+func IsExportFormat(format string) bool {
+	return format == ExportFormatCSV || format == ExportFormatNDJSON
+}
+
+// IsExportOnlyColumn reports whether the column is one an export writes and an import skips
+func IsExportOnlyColumn(name string) bool {
+	switch name {
+	case ExportColumnID, ExportColumnProductType, ExportColumnStatus, ExportColumnBrandID,
+		ExportColumnVersion, ExportColumnCreatedAt, ExportColumnUpdatedAt:
+		return true
+	}
+	return false
+}
+
+// CSVRecord returns the cells of the row in the order of ProductExportColumns
+func (r *ProductExportRow) CSVRecord() []string {
+	categoryIDs := make([]string, len(r.CategoryIDs))
+	for i, id := range r.CategoryIDs {
+		categoryIDs[i] = id.String()
+	}
+
+	return []string{
+		r.ID.String(),
+		r.ProductName,
+		r.Slug,
+		r.ProductType,
+		r.Status,
+		r.Price.String(),
+		r.Price.Currency,
+		strconv.Itoa(r.Quantity),
+		r.BrandID.String(),
+		r.BrandName,
+		strings.Join(categoryIDs, ImportListSeparator),
+		strings.Join(r.Tags, ImportListSeparator),
+		strconv.Itoa(r.Version),
+		r.CreatedAt.Format(time.RFC3339),
+		r.UpdatedAt.Format(time.RFC3339),
+	}
+}
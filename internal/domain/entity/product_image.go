@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"io"
+	"time"
+)
+
+// AllowedImageTypes maps the accepted image content types to the file extension they are stored with
+var AllowedImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+type (
+	ProductImage struct {
+		ID          uuid.UUID `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+		ProductID   uuid.UUID `json:"product_id" gorm:"column:product_id;type:uuid;not null"`
+		StorageKey  string    `json:"-" gorm:"column:storage_key;type:varchar(512);not null"`
+		URL         string    `json:"url" gorm:"column:url;type:text;not null"`
+		ContentType string    `json:"content_type" gorm:"column:content_type;type:varchar(100);not null"`
+		Size        int64     `json:"size" gorm:"type:bigint;not null"`
+		Position    int       `json:"position" gorm:"type:integer;not null"`
+		IsPrimary   bool      `json:"is_primary" gorm:"column:is_primary;not null;default:false"`
+		CreatedAt   time.Time `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		UpdatedAt   time.Time `json:"updated_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+	}
+
+	ProductImageRepository interface {
+		Create(ctx context.Context, image *ProductImage) error
+		GetByID(ctx context.Context, productID, id uuid.UUID) (*ProductImage, error)
+		GetAllByProductID(ctx context.Context, productID uuid.UUID) ([]ProductImage, error)
+		Delete(ctx context.Context, productID, id uuid.UUID) error
+		Reorder(ctx context.Context, productID uuid.UUID, imageIDs []uuid.UUID) error
+		SetPrimary(ctx context.Context, productID, id uuid.UUID) error
+	}
+
+	ProductImageService interface {
+		Upload(ctx context.Context, productID uuid.UUID, req UploadProductImageRequest) (*ProductImageResponse, error)
+		GetAll(ctx context.Context, productID uuid.UUID) ([]ProductImageResponse, error)
+		Reorder(ctx context.Context, productID uuid.UUID, req ReorderProductImagesRequest) ([]ProductImageResponse, error)
+		SetPrimary(ctx context.Context, productID, id uuid.UUID) (*ProductImageResponse, error)
+		Delete(ctx context.Context, productID, id uuid.UUID) error
+	}
+
+	// UploadProductImageRequest carries an uploaded file from the multipart form to the service
+	UploadProductImageRequest struct {
+		Filename  string
+		Size      int64
+		Body      io.Reader
+		IsPrimary bool
+	}
+
+	// ReorderProductImagesRequest lists every image of the product in its new display order
+	ReorderProductImagesRequest struct {
+		ImageIDs []uuid.UUID `json:"image_ids" validate:"required,min=1"`
+	}
+
+	ProductImageResponse struct {
+		ID          uuid.UUID `json:"id"`
+		URL         string    `json:"url"`
+		ContentType string    `json:"content_type"`
+		Size        int64     `json:"size"`
+		Position    int       `json:"position"`
+		IsPrimary   bool      `json:"is_primary"`
+		CreatedAt   string    `json:"created_at"`
+	}
+)
+
+func (*ProductImage) TableName() string {
+	return "product_images"
+}
+
+func (i *ProductImage) ToResponseDTO() *ProductImageResponse {
+	return &ProductImageResponse{
+		ID:          i.ID,
+		URL:         i.URL,
+		ContentType: i.ContentType,
+		Size:        i.Size,
+		Position:    i.Position,
+		IsPrimary:   i.IsPrimary,
+		CreatedAt:   i.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"io"
+)
+
+// MaxImportRows caps the data rows of one import file
+const MaxImportRows = 10000
+
+// What an import row did to the catalog
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+)
+
+// Columns of an import file, the header row names them in any order. List cells separate their
+// values with ImportListSeparator since the comma separates cells.
+const (
+	ImportColumnProductName = "product_name"
+	ImportColumnSlug        = "slug"
+	ImportColumnPrice       = "price"
+	ImportColumnCurrency    = "currency"
+	ImportColumnQuantity    = "quantity"
+	ImportColumnBrandName   = "brand_name"
+	ImportColumnCategoryIDs = "category_ids"
+	ImportColumnTags        = "tags"
+
+	ImportListSeparator = ";"
+)
+
+type (
+	ProductImportService interface {
+		Import(ctx context.Context, file io.Reader, opts ProductImportOptions) (*ProductImportReport, error)
+	}
+
+	ProductImportOptions struct {
+		// DryRun checks every row and reports what would happen without writing anything
+		DryRun bool
+		// CreateBrands creates the brands a row names that do not exist yet instead of rejecting the row
+		CreateBrands bool
+	}
+
+	ProductImportReport struct {
+		DryRun        bool                     `json:"dry_run"`
+		Created       int                      `json:"created"`
+		Updated       int                      `json:"updated"`
+		Failed        int                      `json:"failed"`
+		CreatedBrands []string                 `json:"created_brands,omitempty"`
+		Rows          []ProductImportRowResult `json:"rows"`
+	}
+
+	// ProductImportRowResult is the outcome of the data row on line Row of the file, the header
+	// being line 1
+	ProductImportRowResult struct {
+		Row         int        `json:"row"`
+		ProductName string     `json:"product_name,omitempty"`
+		Action      string     `json:"action,omitempty"`
+		Status      string     `json:"status"`
+		ID          *uuid.UUID `json:"id,omitempty"`
+		Errors      []string   `json:"errors,omitempty"`
+	}
+)
+
+// Tally counts the outcomes of the rows
+func (r *ProductImportReport) Tally() {
+	r.Created, r.Updated, r.Failed = 0, 0, 0
+	for _, row := range r.Rows {
+		switch {
+		case !bulkSucceeded(row.Status):
+			r.Failed++
+		case row.Action == ImportActionCreate:
+			r.Created++
+		default:
+			r.Updated++
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"Unnispick/pkg/money"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+type (
+	// ProductPrice is one period of a product's price. Applied rows are the history, the one
+	// without EffectiveTo is current, and rows not yet applied are scheduled changes
+	ProductPrice struct {
+		ID            uuid.UUID   `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+		ProductID     uuid.UUID   `json:"product_id" gorm:"column:product_id;type:uuid;not null"`
+		Price         money.Money `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+		EffectiveFrom time.Time   `json:"effective_from" gorm:"type:timestamp with time zone;not null"`
+		EffectiveTo   *time.Time  `json:"effective_to,omitempty" gorm:"type:timestamp with time zone"`
+		AppliedAt     *time.Time  `json:"applied_at,omitempty" gorm:"type:timestamp with time zone"`
+		Actor         string      `json:"actor" gorm:"type:varchar(255);not null"`
+		CreatedAt     time.Time   `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+	}
+
+	// AppliedPriceChange is a scheduled change that was applied, with the price it replaced
+	AppliedPriceChange struct {
+		ProductPrice
+		Previous money.Money
+	}
+
+	ProductPriceRepository interface {
+		GetHistoryWithFilter(ctx context.Context, productID uuid.UUID, filter PriceHistoryFilterRepository) (prices []ProductPrice, count int64, err error)
+		GetScheduled(ctx context.Context, productID uuid.UUID) ([]ProductPrice, error)
+		Schedule(ctx context.Context, price *ProductPrice) error
+		CancelScheduled(ctx context.Context, productID, id uuid.UUID) error
+		// ApplyDue applies the changes due by now and returns the ones it applied. A change that
+		// fails stays due and is reported in the error, a change of a trashed product stays due
+		// until the product is restored.
+		ApplyDue(ctx context.Context, now time.Time) ([]AppliedPriceChange, error)
+	}
+
+	ProductPriceService interface {
+		GetHistory(ctx context.Context, productID uuid.UUID, filter PriceHistoryFilterRequest) ([]ProductPriceResponse, int64, error)
+		GetScheduled(ctx context.Context, productID uuid.UUID) ([]ProductPriceResponse, error)
+		Schedule(ctx context.Context, productID uuid.UUID, req SchedulePriceChangeRequest) (*ProductPriceResponse, error)
+		CancelScheduled(ctx context.Context, productID, id uuid.UUID) error
+		ApplyDue(ctx context.Context) (int, error)
+	}
+
+	PriceHistoryFilterRequest struct {
+		Page    int `query:"page"`
+		PerPage int `query:"per_page"`
+	}
+
+	PriceHistoryFilterRepository struct {
+		Limit  int
+		Offset int
+	}
+
+	SchedulePriceChangeRequest struct {
+		Price         money.Money `json:"price" validate:"price"`
+		EffectiveFrom time.Time   `json:"effective_from" validate:"required"`
+	}
+
+	ProductPriceResponse struct {
+		ID            uuid.UUID   `json:"id"`
+		ProductID     uuid.UUID   `json:"product_id"`
+		Price         money.Money `json:"price"`
+		EffectiveFrom string      `json:"effective_from"`
+		EffectiveTo   *string     `json:"effective_to"`
+		Scheduled     bool        `json:"scheduled"`
+		Actor         string      `json:"actor"`
+		CreatedAt     string      `json:"created_at"`
+	}
+)
+
+func (*ProductPrice) TableName() string {
+	return "product_prices"
+}
+
+func (req PriceHistoryFilterRequest) ToPriceHistoryFilterRepo() PriceHistoryFilterRepository {
+	return PriceHistoryFilterRepository{
+		Limit:  req.PerPage,
+		Offset: (req.Page - 1) * req.PerPage,
+	}
+}
+
+func (p *ProductPrice) ToResponseDTO() *ProductPriceResponse {
+	response := &ProductPriceResponse{
+		ID:            p.ID,
+		ProductID:     p.ProductID,
+		Price:         p.Price,
+		EffectiveFrom: p.EffectiveFrom.Format(time.RFC3339),
+		Scheduled:     p.AppliedAt == nil,
+		Actor:         p.Actor,
+		CreatedAt:     p.CreatedAt.Format(time.RFC3339),
+	}
+
+	if p.EffectiveTo != nil {
+		effectiveTo := p.EffectiveTo.Format(time.RFC3339)
+		response.EffectiveTo = &effectiveTo
+	}
+
+	return response
+}
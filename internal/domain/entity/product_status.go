@@ -0,0 +1,39 @@
+package entity
+
+const (
+	ProductStatusDraft        = "draft"
+	ProductStatusActive       = "active"
+	ProductStatusDiscontinued = "discontinued"
+	ProductStatusArchived     = "archived"
+
+	// ProductStatusAll lists products in every status, for admin views
+	ProductStatusAll = "all"
+)
+
+// productStatusTransitions lists the statuses a product may move to from each status. Archived is
+// final.
+var productStatusTransitions = map[string][]string{
+	ProductStatusDraft:        {ProductStatusActive, ProductStatusArchived},
+	ProductStatusActive:       {ProductStatusDiscontinued, ProductStatusArchived},
+	ProductStatusDiscontinued: {ProductStatusActive, ProductStatusArchived},
+}
+
+// IsProductStatus reports whether status is one of the product lifecycle statuses
+func IsProductStatus(status string) bool {
+	switch status {
+	case ProductStatusDraft, ProductStatusActive, ProductStatusDiscontinued, ProductStatusArchived:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransitionTo reports whether the product may move from its current status to status
+func (p *Product) CanTransitionTo(status string) bool {
+	for _, next := range productStatusTransitions[p.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"Unnispick/pkg/money"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+type (
+	ProductVariant struct {
+		ID          uuid.UUID   `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+		ProductID   uuid.UUID   `json:"product_id" gorm:"column:product_id;type:uuid;not null"`
+		SKU         string      `json:"sku" gorm:"column:sku;type:varchar(100);not null;uniqueIndex"`
+		VariantName string      `json:"variant_name" gorm:"column:variant_name;type:varchar(255);not null"`
+		Shade       string      `json:"shade" gorm:"type:varchar(100)"`
+		Size        string      `json:"size" gorm:"type:varchar(50)"`
+		Volume      string      `json:"volume" gorm:"type:varchar(50)"`
+		Price       money.Money `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+		Quantity    int         `json:"quantity" gorm:"type:integer;not null;check:quantity >= 0"`
+		CreatedAt   time.Time   `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		UpdatedAt   time.Time   `json:"updated_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+	}
+
+	ProductVariantRepository interface {
+		Create(ctx context.Context, variant *ProductVariant) error
+		GetByID(ctx context.Context, productID, id uuid.UUID) (*ProductVariant, error)
+		GetAllByProductID(ctx context.Context, productID uuid.UUID) ([]ProductVariant, error)
+		Update(ctx context.Context, variant *ProductVariant) error
+		Delete(ctx context.Context, productID, id uuid.UUID) error
+		ExistsBySKU(ctx context.Context, sku string) (bool, error)
+	}
+
+	ProductVariantService interface {
+		Create(ctx context.Context, productID uuid.UUID, req CreateProductVariantRequest) (*ProductVariantResponse, error)
+		GetByID(ctx context.Context, productID, id uuid.UUID) (*ProductVariantResponse, error)
+		GetAll(ctx context.Context, productID uuid.UUID) ([]ProductVariantResponse, error)
+		Update(ctx context.Context, productID, id uuid.UUID, req UpdateProductVariantRequest) (*ProductVariantResponse, error)
+		Delete(ctx context.Context, productID, id uuid.UUID) error
+	}
+
+	CreateProductVariantRequest struct {
+		SKU         string      `json:"sku" validate:"required,min=1,max=100"`
+		VariantName string      `json:"variant_name" validate:"required,min=1,max=255"`
+		Shade       string      `json:"shade" validate:"omitempty,max=100"`
+		Size        string      `json:"size" validate:"omitempty,max=50"`
+		Volume      string      `json:"volume" validate:"omitempty,max=50"`
+		Price       money.Money `json:"price" validate:"price"`
+		Quantity    int         `json:"quantity" validate:"gte=0"`
+	}
+
+	UpdateProductVariantRequest struct {
+		SKU         string      `json:"sku" validate:"required,min=1,max=100"`
+		VariantName string      `json:"variant_name" validate:"required,min=1,max=255"`
+		Shade       string      `json:"shade" validate:"omitempty,max=100"`
+		Size        string      `json:"size" validate:"omitempty,max=50"`
+		Volume      string      `json:"volume" validate:"omitempty,max=50"`
+		Price       money.Money `json:"price" validate:"price"`
+		Quantity    int         `json:"quantity" validate:"gte=0"`
+	}
+
+	ProductVariantResponse struct {
+		ID          uuid.UUID   `json:"id"`
+		ProductID   uuid.UUID   `json:"product_id"`
+		SKU         string      `json:"sku"`
+		VariantName string      `json:"variant_name"`
+		Shade       string      `json:"shade,omitempty"`
+		Size        string      `json:"size,omitempty"`
+		Volume      string      `json:"volume,omitempty"`
+		Price       money.Money `json:"price"`
+		Quantity    int         `json:"quantity"`
+		CreatedAt   string      `json:"created_at"`
+		UpdatedAt   string      `json:"updated_at"`
+	}
+
+	PriceRange struct {
+		Min money.Money `json:"min"`
+		Max money.Money `json:"max"`
+	}
+)
+
+func (*ProductVariant) TableName() string {
+	return "product_variants"
+}
+
+func (req *CreateProductVariantRequest) ToProductVariantEntity(productID uuid.UUID) *ProductVariant {
+	return &ProductVariant{
+		ProductID:   productID,
+		SKU:         req.SKU,
+		VariantName: req.VariantName,
+		Shade:       req.Shade,
+		Size:        req.Size,
+		Volume:      req.Volume,
+		Price:       req.Price,
+		Quantity:    req.Quantity,
+	}
+}
+
+func (v *ProductVariant) UpdateFromRequest(req UpdateProductVariantRequest) {
+	v.SKU = req.SKU
+	v.VariantName = req.VariantName
+	v.Shade = req.Shade
+	v.Size = req.Size
+	v.Volume = req.Volume
+	v.Price = req.Price
+	v.Quantity = req.Quantity
+}
+
+func (v *ProductVariant) ToResponseDTO() *ProductVariantResponse {
+	return &ProductVariantResponse{
+		ID:          v.ID,
+		ProductID:   v.ProductID,
+		SKU:         v.SKU,
+		VariantName: v.VariantName,
+		Shade:       v.Shade,
+		Size:        v.Size,
+		Volume:      v.Volume,
+		Price:       v.Price,
+		Quantity:    v.Quantity,
+		CreatedAt:   v.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   v.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,255 @@
+package entity
+
+import (
+	"Unnispick/pkg/money"
+	"context"
+	"github.com/google/uuid"
+	"math/big"
+	"regexp"
+	"time"
+)
+
+const (
+	DiscountTypePercentage = "percentage"
+	DiscountTypeFixed      = "fixed"
+
+	PromotionScopeProduct  = "product"
+	PromotionScopeBrand    = "brand"
+	PromotionScopeCategory = "category"
+)
+
+type (
+	// Promotion discounts every product in its scope between StartsAt and EndsAt. Running
+	// promotions are applied from the highest priority down: a stackable promotion combines with
+	// the other stackable ones, a promotion that is not stackable only applies on its own
+	Promotion struct {
+		ID            uuid.UUID   `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+		PromotionName string      `json:"promotion_name" gorm:"column:promotion_name;type:varchar(255);not null"`
+		DiscountType  string      `json:"discount_type" gorm:"type:varchar(20);not null"`
+		Percentage    string      `json:"percentage" gorm:"type:numeric(5,2);not null;default:0"`
+		AmountOff     money.Money `json:"amount_off" gorm:"embedded;embeddedPrefix:amount_off_"`
+		ScopeType     string      `json:"scope_type" gorm:"type:varchar(20);not null"`
+		ScopeID       uuid.UUID   `json:"scope_id" gorm:"type:uuid;not null"`
+		StartsAt      time.Time   `json:"starts_at" gorm:"type:timestamp with time zone;not null"`
+		EndsAt        *time.Time  `json:"ends_at,omitempty" gorm:"type:timestamp with time zone"`
+		Priority      int         `json:"priority" gorm:"type:integer;not null;default:0"`
+		Stackable     bool        `json:"stackable" gorm:"not null;default:false"`
+		CreatedAt     time.Time   `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		UpdatedAt     time.Time   `json:"updated_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+	}
+
+	PromotionRepository interface {
+		Create(ctx context.Context, promotion *Promotion) error
+		GetByID(ctx context.Context, id uuid.UUID) (*Promotion, error)
+		GetAllWithFilter(ctx context.Context, filter PromotionFilterRepository) (promotions []Promotion, count int64, err error)
+		Update(ctx context.Context, promotion *Promotion) error
+		Delete(ctx context.Context, id uuid.UUID) error
+		GetActive(ctx context.Context, at time.Time) ([]Promotion, error)
+	}
+
+	PromotionService interface {
+		Create(ctx context.Context, req CreatePromotionRequest) (*PromotionResponse, error)
+		GetByID(ctx context.Context, id uuid.UUID) (*PromotionResponse, error)
+		GetAll(ctx context.Context, filter PromotionFilterRequest) ([]PromotionResponse, int64, error)
+		Update(ctx context.Context, id uuid.UUID, req UpdatePromotionRequest) (*PromotionResponse, error)
+		Delete(ctx context.Context, id uuid.UUID) error
+	}
+
+	// PricingEvaluator fills in the final price and applied promotions of products at a point in time
+	PricingEvaluator interface {
+		Evaluate(ctx context.Context, at time.Time, products ...*ProductResponse) error
+	}
+
+	PromotionFilterRequest struct {
+		ScopeType  string    `query:"scope_type"`
+		ScopeID    uuid.UUID `query:"scope_id"`
+		ActiveOnly bool      `query:"active"`
+		Page       int       `query:"page"`
+		PerPage    int       `query:"per_page"`
+	}
+
+	PromotionFilterRepository struct {
+		ScopeType string
+		ScopeID   uuid.UUID
+		ActiveAt  *time.Time
+		Limit     int
+		Offset    int
+	}
+
+	CreatePromotionRequest struct {
+		PromotionName string       `json:"promotion_name" validate:"required,min=1,max=255"`
+		DiscountType  string       `json:"discount_type" validate:"required,oneof=percentage fixed"`
+		Percentage    string       `json:"percentage" validate:"required_if=DiscountType percentage"`
+		AmountOff     *money.Money `json:"amount_off" validate:"required_if=DiscountType fixed"`
+		ScopeType     string       `json:"scope_type" validate:"required,oneof=product brand category"`
+		ScopeID       uuid.UUID    `json:"scope_id" validate:"required"`
+		StartsAt      time.Time    `json:"starts_at" validate:"required"`
+		EndsAt        *time.Time   `json:"ends_at"`
+		Priority      int          `json:"priority"`
+		Stackable     bool         `json:"stackable"`
+	}
+
+	UpdatePromotionRequest struct {
+		PromotionName string       `json:"promotion_name" validate:"required,min=1,max=255"`
+		DiscountType  string       `json:"discount_type" validate:"required,oneof=percentage fixed"`
+		Percentage    string       `json:"percentage" validate:"required_if=DiscountType percentage"`
+		AmountOff     *money.Money `json:"amount_off" validate:"required_if=DiscountType fixed"`
+		ScopeType     string       `json:"scope_type" validate:"required,oneof=product brand category"`
+		ScopeID       uuid.UUID    `json:"scope_id" validate:"required"`
+		StartsAt      time.Time    `json:"starts_at" validate:"required"`
+		EndsAt        *time.Time   `json:"ends_at"`
+		Priority      int          `json:"priority"`
+		Stackable     bool         `json:"stackable"`
+	}
+
+	PromotionResponse struct {
+		ID            uuid.UUID    `json:"id"`
+		PromotionName string       `json:"promotion_name"`
+		DiscountType  string       `json:"discount_type"`
+		Percentage    string       `json:"percentage,omitempty"`
+		AmountOff     *money.Money `json:"amount_off,omitempty"`
+		ScopeType     string       `json:"scope_type"`
+		ScopeID       uuid.UUID    `json:"scope_id"`
+		StartsAt      string       `json:"starts_at"`
+		EndsAt        *string      `json:"ends_at"`
+		Priority      int          `json:"priority"`
+		Stackable     bool         `json:"stackable"`
+		CreatedAt     string       `json:"created_at"`
+		UpdatedAt     string       `json:"updated_at"`
+	}
+
+	// AppliedPromotionResponse is one promotion taken off a product price, in the product currency
+	AppliedPromotionResponse struct {
+		ID            uuid.UUID   `json:"id"`
+		PromotionName string      `json:"promotion_name"`
+		DiscountType  string      `json:"discount_type"`
+		Discount      money.Money `json:"discount"`
+	}
+)
+
+func (*Promotion) TableName() string {
+	return "promotions"
+}
+
+func (req PromotionFilterRequest) ToPromotionFilterRepo() PromotionFilterRepository {
+	filter := PromotionFilterRepository{
+		ScopeType: req.ScopeType,
+		ScopeID:   req.ScopeID,
+		Limit:     req.PerPage,
+		Offset:    (req.Page - 1) * req.PerPage,
+	}
+	if req.ActiveOnly {
+		now := time.Now()
+		filter.ActiveAt = &now
+	}
+	return filter
+}
+
+// PercentageRate returns the percentage as an exact fraction, 20 becoming 1/5
+func (p *Promotion) PercentageRate() (*big.Rat, error) {
+	return parsePercentage(p.Percentage)
+}
+
+func (req *CreatePromotionRequest) ToPromotionEntity() *Promotion {
+	promotion := &Promotion{}
+	promotion.applyRequest(UpdatePromotionRequest(*req))
+	return promotion
+}
+
+func (p *Promotion) UpdateFromRequest(req UpdatePromotionRequest) {
+	p.applyRequest(req)
+}
+
+func (p *Promotion) applyRequest(req UpdatePromotionRequest) {
+	p.PromotionName = req.PromotionName
+	p.DiscountType = req.DiscountType
+	p.ScopeType = req.ScopeType
+	p.ScopeID = req.ScopeID
+	p.StartsAt = req.StartsAt
+	p.EndsAt = req.EndsAt
+	p.Priority = req.Priority
+	p.Stackable = req.Stackable
+
+	// Only the value matching the discount type is kept
+	p.Percentage = "0"
+	p.AmountOff = money.Money{}
+	switch req.DiscountType {
+	case DiscountTypePercentage:
+		if _, err := parsePercentage(req.Percentage); err == nil {
+			p.Percentage = req.Percentage
+		}
+	case DiscountTypeFixed:
+		if req.AmountOff != nil {
+			p.AmountOff = *req.AmountOff
+		}
+	}
+}
+
+func (p *Promotion) ToResponseDTO() *PromotionResponse {
+	response := &PromotionResponse{
+		ID:            p.ID,
+		PromotionName: p.PromotionName,
+		DiscountType:  p.DiscountType,
+		ScopeType:     p.ScopeType,
+		ScopeID:       p.ScopeID,
+		StartsAt:      p.StartsAt.Format(time.RFC3339),
+		Priority:      p.Priority,
+		Stackable:     p.Stackable,
+		CreatedAt:     p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     p.UpdatedAt.Format(time.RFC3339),
+	}
+
+	switch p.DiscountType {
+	case DiscountTypePercentage:
+		response.Percentage = p.Percentage
+	case DiscountTypeFixed:
+		amountOff := p.AmountOff
+		response.AmountOff = &amountOff
+	}
+
+	if p.EndsAt != nil {
+		endsAt := p.EndsAt.Format(time.RFC3339)
+		response.EndsAt = &endsAt
+	}
+
+	return response
+}
+
+func (req *CreatePromotionRequest) Validate() error {
+	update := UpdatePromotionRequest(*req)
+	return update.Validate()
+}
+
+func (req *UpdatePromotionRequest) Validate() error {
+	switch req.DiscountType {
+	case DiscountTypePercentage:
+		if _, err := parsePercentage(req.Percentage); err != nil {
+			return err
+		}
+	case DiscountTypeFixed:
+		if req.AmountOff == nil || !req.AmountOff.IsPositive() || !money.IsSupported(req.AmountOff.Currency) {
+			return ErrInvalidAmountOff
+		}
+	}
+
+	if req.EndsAt != nil && !req.EndsAt.After(req.StartsAt) {
+		return ErrInvalidPromotionPeriod
+	}
+	return nil
+}
+
+// percentagePattern is a plain decimal that fits the numeric(5,2) column. big.Rat alone would
+// also take fractions like "1/3" and exponents.
+var percentagePattern = regexp.MustCompile(`^[0-9]{1,3}(\.[0-9]{1,2})?$`)
+
+// parsePercentage reads a percentage like "12.5" into the fraction 1/8
+func parsePercentage(value string) (*big.Rat, error) {
+	if !percentagePattern.MatchString(value) {
+		return nil, ErrInvalidPercentage
+	}
+	percentage, ok := new(big.Rat).SetString(value)
+	if !ok || percentage.Sign() <= 0 || percentage.Cmp(big.NewRat(100, 1)) > 0 {
+		return nil, ErrInvalidPercentage
+	}
+	return percentage.Quo(percentage, big.NewRat(100, 1)), nil
+}
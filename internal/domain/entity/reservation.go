@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	ReservationStatusPending   = "pending"
+	ReservationStatusConfirmed = "confirmed"
+	ReservationStatusReleased  = "released"
+	ReservationStatusExpired   = "expired"
+
+	StockReasonReservationConfirmed = "reservation_confirmed"
+)
+
+type (
+	Reservation struct {
+		ID        uuid.UUID `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+		ProductID uuid.UUID `json:"product_id" gorm:"column:product_id;type:uuid;not null"`
+		Quantity  int       `json:"quantity" gorm:"type:integer;not null;check:quantity > 0"`
+		Status    string    `json:"status" gorm:"type:varchar(20);not null"`
+		Reference string    `json:"reference" gorm:"type:varchar(255)"`
+		ExpiresAt time.Time `json:"expires_at" gorm:"type:timestamp with time zone;not null"`
+		CreatedAt time.Time `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		UpdatedAt time.Time `json:"updated_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+	}
+
+	ReservationRepository interface {
+		Create(ctx context.Context, reservation *Reservation) error
+		GetByID(ctx context.Context, id uuid.UUID) (*Reservation, error)
+		Confirm(ctx context.Context, id uuid.UUID) (*Reservation, error)
+		Release(ctx context.Context, id uuid.UUID) (*Reservation, error)
+		ExpireStale(ctx context.Context, now time.Time) (int64, error)
+	}
+
+	ReservationService interface {
+		Create(ctx context.Context, productID uuid.UUID, req CreateReservationRequest) (*ReservationResponse, error)
+		GetByID(ctx context.Context, id uuid.UUID) (*ReservationResponse, error)
+		Confirm(ctx context.Context, id uuid.UUID) (*ReservationResponse, error)
+		Release(ctx context.Context, id uuid.UUID) (*ReservationResponse, error)
+		ExpireStale(ctx context.Context) (int64, error)
+	}
+
+	CreateReservationRequest struct {
+		Quantity   int    `json:"quantity" validate:"required,gt=0"`
+		Reference  string `json:"reference" validate:"omitempty,max=255"`
+		TTLSeconds int    `json:"ttl_seconds" validate:"omitempty,gt=0"`
+	}
+
+	ReservationResponse struct {
+		ID        uuid.UUID `json:"id"`
+		ProductID uuid.UUID `json:"product_id"`
+		Quantity  int       `json:"quantity"`
+		Status    string    `json:"status"`
+		Reference string    `json:"reference,omitempty"`
+		ExpiresAt string    `json:"expires_at"`
+		CreatedAt string    `json:"created_at"`
+		UpdatedAt string    `json:"updated_at"`
+	}
+)
+
+func (*Reservation) TableName() string {
+	return "stock_reservations"
+}
+
+func (req *CreateReservationRequest) Validate() error {
+	if req.Quantity <= 0 {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
+func (req *CreateReservationRequest) ToReservationEntity(productID uuid.UUID, expiresAt time.Time) *Reservation {
+	return &Reservation{
+		ProductID: productID,
+		Quantity:  req.Quantity,
+		Status:    ReservationStatusPending,
+		Reference: req.Reference,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (r *Reservation) ToResponseDTO() *ReservationResponse {
+	return &ReservationResponse{
+		ID:        r.ID,
+		ProductID: r.ProductID,
+		Quantity:  r.Quantity,
+		Status:    r.Status,
+		Reference: r.Reference,
+		ExpiresAt: r.ExpiresAt.Format(time.RFC3339),
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: r.UpdatedAt.Format(time.RFC3339),
+	}
+}
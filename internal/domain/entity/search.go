@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"html"
+	"strings"
+)
+
+// Markers around the words a text search matched in a highlighted fragment
+const (
+	SearchHighlightStart = "<mark>"
+	SearchHighlightStop  = "</mark>"
+)
+
+// Markers the database puts around matched words. They are private use characters that are
+// dropped from the text before it is marked, so they survive escaping the fragment to HTML and
+// are then swapped for SearchHighlightStart and SearchHighlightStop.
+const (
+	SearchMatchStart = "\ue000"
+	SearchMatchStop  = "\ue001"
+)
+
+// HighlightHTML escapes a fragment marked with SearchMatchStart and SearchMatchStop to HTML, the
+// matched words wrapped in SearchHighlightStart and SearchHighlightStop
+func HighlightHTML(fragment string) string {
+	return strings.NewReplacer(
+		SearchMatchStart, SearchHighlightStart,
+		SearchMatchStop, SearchHighlightStop,
+	).Replace(html.EscapeString(fragment))
+}
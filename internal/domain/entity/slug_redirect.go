@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	SlugEntityBrand   = "brand"
+	SlugEntityProduct = "product"
+)
+
+type (
+	// SlugRedirect remembers a slug an entity was renamed away from, so old URLs keep resolving
+	SlugRedirect struct {
+		EntityType string    `gorm:"column:entity_type;type:varchar(20);primaryKey"`
+		Slug       string    `gorm:"type:varchar(255);primaryKey"`
+		EntityID   uuid.UUID `gorm:"column:entity_id;type:uuid;not null;index"`
+		CreatedAt  time.Time `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+	}
+
+	SlugRedirectRepository interface {
+		GetBySlug(ctx context.Context, entityType, slug string) (*SlugRedirect, error)
+	}
+
+	SlugRedirectResponse struct {
+		Slug     string `json:"slug"`
+		Location string `json:"location"`
+	}
+
+	// SlugMovedError is returned when a lookup hits an old slug, Slug is the one to use instead
+	SlugMovedError struct {
+		Slug string
+	}
+)
+
+func (*SlugRedirect) TableName() string {
+	return "slug_redirects"
+}
+
+func (e *SlugMovedError) Error() string {
+	return fmt.Sprintf("slug has moved to %s", e.Slug)
+}
@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	StockReasonOpeningBalance = "opening_balance"
+	StockReasonInitialStock   = "initial_stock"
+	StockReasonProductUpdate  = "product_update"
+	StockReasonIncrease       = "stock_increase"
+	StockReasonDecrease       = "stock_decrease"
+)
+
+type (
+	StockMovement struct {
+		ID        uuid.UUID `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+		ProductID uuid.UUID `json:"product_id" gorm:"column:product_id;type:uuid;not null"`
+		Delta     int       `json:"delta" gorm:"type:integer;not null"`
+		Reason    string    `json:"reason" gorm:"type:varchar(50);not null"`
+		Reference string    `json:"reference" gorm:"type:varchar(255)"`
+		Actor     string    `json:"actor" gorm:"type:varchar(255);not null"`
+		CreatedAt time.Time `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+	}
+
+	// StockMovementNote describes why a quantity change happened, the actor is taken from the context
+	StockMovementNote struct {
+		Reason    string
+		Reference string
+	}
+
+	// StockDiscrepancy is a product whose quantity does not match the sum of its ledger
+	StockDiscrepancy struct {
+		ProductID      uuid.UUID `json:"product_id"`
+		ProductName    string    `json:"product_name"`
+		Quantity       int       `json:"quantity"`
+		LedgerQuantity int       `json:"ledger_quantity"`
+	}
+
+	StockMovementRepository interface {
+		GetAllByProductWithFilter(ctx context.Context, productID uuid.UUID, filter StockMovementFilterRepository) (movements []StockMovement, count int64, err error)
+		Reconcile(ctx context.Context) ([]StockDiscrepancy, error)
+	}
+
+	StockMovementFilterRequest struct {
+		Page    int `query:"page"`
+		PerPage int `query:"per_page"`
+	}
+
+	StockMovementFilterRepository struct {
+		Limit  int
+		Offset int
+	}
+
+	StockMovementResponse struct {
+		ID        uuid.UUID `json:"id"`
+		ProductID uuid.UUID `json:"product_id"`
+		Delta     int       `json:"delta"`
+		Reason    string    `json:"reason"`
+		Reference string    `json:"reference,omitempty"`
+		Actor     string    `json:"actor"`
+		CreatedAt string    `json:"created_at"`
+	}
+)
+
+func (*StockMovement) TableName() string {
+	return "stock_movements"
+}
+
+func (req StockMovementFilterRequest) ToStockMovementFilterRepo() StockMovementFilterRepository {
+	return StockMovementFilterRepository{
+		Limit:  req.PerPage,
+		Offset: (req.Page - 1) * req.PerPage,
+	}
+}
+
+func (m *StockMovement) ToResponseDTO() *StockMovementResponse {
+	return &StockMovementResponse{
+		ID:        m.ID,
+		ProductID: m.ProductID,
+		Delta:     m.Delta,
+		Reason:    m.Reason,
+		Reference: m.Reference,
+		Actor:     m.Actor,
+		CreatedAt: m.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// What a suggestion points at
+const (
+	SuggestionTypeBrand   = "brand"
+	SuggestionTypeProduct = "product"
+)
+
+const (
+	// DefaultSuggestionLimit and MaxSuggestionLimit bound how many suggestions one request returns
+	DefaultSuggestionLimit = 10
+	MaxSuggestionLimit     = 20
+	// MaxSuggestionQueryLength caps the text a suggestion is looked up for
+	MaxSuggestionQueryLength = 100
+	// DefaultSuggestionThreshold is the word similarity a name needs when none is configured
+	DefaultSuggestionThreshold = 0.4
+)
+
+type (
+	SuggestionRepository interface {
+		// Suggest returns the live brands and active products whose name is at least threshold
+		// similar to the text, the most similar first
+		Suggest(ctx context.Context, text string, threshold float64, limit int) ([]Suggestion, error)
+	}
+
+	SuggestionService interface {
+		Suggest(ctx context.Context, text string, limit int) ([]Suggestion, error)
+	}
+
+	// Suggestion is a brand or product whose name resembles what was typed, Similarity runs from 0
+	// to 1
+	Suggestion struct {
+		Type       string    `json:"type"`
+		ID         uuid.UUID `json:"id"`
+		Name       string    `json:"name"`
+		Slug       string    `json:"slug"`
+		Similarity float64   `json:"similarity"`
+	}
+)
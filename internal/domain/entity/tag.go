@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	TagModeAny = "any"
+	TagModeAll = "all"
+)
+
+type (
+	Tag struct {
+		ID        uuid.UUID `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+		TagName   string    `json:"tag_name" gorm:"column:tag_name;type:varchar(100);not null;uniqueIndex"`
+		CreatedAt time.Time `json:"created_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+		UpdatedAt time.Time `json:"updated_at" gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP"`
+	}
+
+	ProductTag struct {
+		ProductID uuid.UUID `gorm:"column:product_id;type:uuid;primaryKey"`
+		TagID     uuid.UUID `gorm:"column:tag_id;type:uuid;primaryKey"`
+	}
+
+	TagRepository interface {
+		Create(ctx context.Context, tag *Tag) error
+		GetByID(ctx context.Context, id uuid.UUID) (*Tag, error)
+		GetAllWithFilter(ctx context.Context, filter TagFilterRepository) (tags []Tag, count int64, err error)
+		Update(ctx context.Context, tag *Tag) error
+		Delete(ctx context.Context, id uuid.UUID) error
+		ExistsByName(ctx context.Context, name string) (bool, error)
+		FindOrCreateByNames(ctx context.Context, names []string) ([]Tag, error)
+	}
+
+	TagService interface {
+		Create(ctx context.Context, req CreateTagRequest) (*TagResponse, error)
+		GetAll(ctx context.Context, filter TagFilterRequest) ([]TagResponse, int64, error)
+		Update(ctx context.Context, id uuid.UUID, req UpdateTagRequest) (*TagResponse, error)
+		Delete(ctx context.Context, id uuid.UUID) error
+	}
+
+	TagFilterRequest struct {
+		Search  string `query:"search"`
+		Page    int    `query:"page"`
+		PerPage int    `query:"per_page"`
+	}
+
+	TagFilterRepository struct {
+		Search string
+		Limit  int
+		Offset int
+	}
+
+	CreateTagRequest struct {
+		TagName string `json:"tag_name" validate:"required,min=1,max=100"`
+	}
+
+	UpdateTagRequest struct {
+		TagName string `json:"tag_name" validate:"required,min=1,max=100"`
+	}
+
+	TagResponse struct {
+		ID        uuid.UUID `json:"id"`
+		TagName   string    `json:"tag_name"`
+		CreatedAt string    `json:"created_at"`
+		UpdatedAt string    `json:"updated_at"`
+	}
+)
+
+func (*Tag) TableName() string {
+	return "tags"
+}
+
+func (*ProductTag) TableName() string {
+	return "product_tags"
+}
+
+// NormalizeTagName turns free-form input like " Sensitive Skin " into "sensitive-skin"
+func NormalizeTagName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+// NormalizeTagNames normalizes and de-duplicates tag names, dropping empty ones
+func NormalizeTagNames(names []string) []string {
+	if names == nil {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeTagName(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
+
+// TagIDs returns the IDs of the tags in order
+func TagIDs(tags []Tag) []uuid.UUID {
+	ids := make([]uuid.UUID, len(tags))
+	for i, tag := range tags {
+		ids[i] = tag.ID
+	}
+	return ids
+}
+
+func (req TagFilterRequest) ToTagFilterRepo() TagFilterRepository {
+	return TagFilterRepository{
+		Search: req.Search,
+		Limit:  req.PerPage,
+		Offset: (req.Page - 1) * req.PerPage,
+	}
+}
+
+func (req *CreateTagRequest) ToTagEntity() *Tag {
+	return &Tag{
+		TagName: NormalizeTagName(req.TagName),
+	}
+}
+
+func (t *Tag) UpdateFromRequest(req UpdateTagRequest) {
+	if name := NormalizeTagName(req.TagName); name != "" {
+		t.TagName = name
+	}
+}
+
+func (t *Tag) ToResponseDTO() *TagResponse {
+	return &TagResponse{
+		ID:        t.ID,
+		TagName:   t.TagName,
+		CreatedAt: t.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: t.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (req *CreateTagRequest) Validate() error {
+	if NormalizeTagName(req.TagName) == "" {
+		return ErrEmptyTagName
+	}
+	return nil
+}
+
+func (req *UpdateTagRequest) Validate() error {
+	if NormalizeTagName(req.TagName) == "" {
+		return ErrEmptyTagName
+	}
+	return nil
+}
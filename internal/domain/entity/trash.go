@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+type (
+	TrashFilterRequest struct {
+		Search  string `query:"search"`
+		Page    int    `query:"page"`
+		PerPage int    `query:"per_page"`
+	}
+
+	TrashFilterRepository struct {
+		Search string
+		Limit  int
+		Offset int
+	}
+)
+
+func (req TrashFilterRequest) ToTrashFilterRepo() TrashFilterRepository {
+	return TrashFilterRepository{
+		Search: req.Search,
+		Limit:  req.PerPage,
+		Offset: (req.Page - 1) * req.PerPage,
+	}
+}
+
+// formatDeletedAt renders the soft delete time of a trashed row, and nothing for a live one
+func formatDeletedAt(deletedAt gorm.DeletedAt) string {
+	if !deletedAt.Valid {
+		return ""
+	}
+	return deletedAt.Time.Format(time.RFC3339)
+}
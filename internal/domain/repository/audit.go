@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type auditRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewAuditRepository(db *gorm.DB, tracer *tracing.Tracer) entity.AuditRepository {
+	return &auditRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+func (r *auditRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	ctx, span := r.tracer.Start(ctx, "repository.audit.Create")
+	defer span.End()
+
+	if err := r.db.WithContext(ctx).Create(log).Error; err != nil {
+		tracer.RecordError(span, err)
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+
+	return nil
+}
+
+func (r *auditRepository) GetAllWithFilter(ctx context.Context, filter entity.AuditFilterRepository) (logs []entity.AuditLog, count int64, err error) {
+	ctx, span := r.tracer.Start(ctx, "repository.audit.GetAllWithFilter")
+	defer span.End()
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("invalid pagination parameters: limit and offset must be non-negative")
+	}
+
+	query := r.db.WithContext(ctx).Model(&entity.AuditLog{})
+
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != uuid.Nil {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	if err = query.Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	if count > 0 && filter.Offset >= int(count) {
+		return []entity.AuditLog{}, count, nil
+	}
+
+	if err = query.
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Order("created_at DESC").
+		Find(&logs).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	return logs, count, nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
@@ -29,6 +30,9 @@ func (r *brandRepository) Create(ctx context.Context, brand *entity.Brand) error
 
 	if err := r.db.WithContext(ctx).Create(brand).Error; err != nil {
 		tracer.RecordError(span, err)
+		if isUniqueViolation(err, brandSlugIndex) {
+			return fmt.Errorf("%w: %s", entity.ErrBrandSlugTaken, brand.Slug)
+		}
 		return fmt.Errorf("failed to create brand: %w", err)
 	}
 
@@ -46,8 +50,13 @@ func (r *brandRepository) GetAllWithFilter(ctx context.Context, filter entity.Br
 	query := r.db.WithContext(ctx).Model(&entity.Brand{})
 
 	// Apply search filter
-	if filter.Search != "" {
-		query = query.Where("brand_name ILIKE ?", "%"+filter.Search+"%")
+	search := searchQuery(filter.Search)
+	if search != "" {
+		query = query.Where("search_vector @@ "+tsQuerySQL, search)
+	}
+
+	if filter.Country != "" {
+		query = query.Where("country = ?", filter.Country)
 	}
 
 	// Count total records
@@ -61,6 +70,14 @@ func (r *brandRepository) GetAllWithFilter(ctx context.Context, filter entity.Br
 		return []entity.Brand{}, count, nil
 	}
 
+	// The best matches of a search come first
+	if search != "" {
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(search_vector, " + tsQuerySQL + ") DESC",
+			Vars: []interface{}{search},
+		}})
+	}
+
 	// Get paginated records
 	if err = query.
 		Limit(filter.Limit).
@@ -94,30 +111,84 @@ func (r *brandRepository) Update(ctx context.Context, brand *entity.Brand) error
 	ctx, span := r.tracer.Start(ctx, "repository.brand.Update")
 	defer span.End()
 
-	result := r.db.WithContext(ctx).Model(brand).Updates(map[string]interface{}{
-		"brand_name": brand.BrandName,
-		"updated_at": time.Now(),
-	})
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the row so the changed columns and the slug we keep as a redirect are compared with
+		// the values we overwrite
+		var current entity.Brand
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "id = ?", brand.ID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("brand not found")
+			}
+			return fmt.Errorf("failed to update brand: %w", err)
+		}
 
-	if result.Error != nil {
-		tracer.RecordError(span, result.Error)
-		return fmt.Errorf("failed to update brand: %w", result.Error)
-	}
+		// The brand carries the version it was read at, another write since then bumped it
+		now := time.Now()
+		changes := brandChanges(&current, brand)
+		changes["version"] = gorm.Expr("version + 1")
+		changes["updated_at"] = now
+
+		result := tx.Model(&entity.Brand{}).Where("id = ? AND version = ?", brand.ID, brand.Version).Updates(changes)
+		if result.Error != nil {
+			if isUniqueViolation(result.Error, brandSlugIndex) {
+				return fmt.Errorf("%w: %s", entity.ErrBrandSlugTaken, brand.Slug)
+			}
+			return fmt.Errorf("failed to update brand: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: brand is at version %d", entity.ErrVersionMismatch, current.Version)
+		}
+		brand.Version++
+		brand.UpdatedAt = now
 
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("brand not found")
+		if current.Slug != brand.Slug {
+			return moveSlug(ctx, tx, entity.SlugEntityBrand, brand.ID, current.Slug, brand.Slug)
+		}
+		return nil
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
 	}
 
 	return nil
 }
 
-func (r *brandRepository) Delete(ctx context.Context, id uuid.UUID) error {
+// brandChanges maps the columns whose value differs from the stored brand to their new value
+func brandChanges(current, brand *entity.Brand) map[string]interface{} {
+	changes := map[string]interface{}{}
+	if brand.BrandName != current.BrandName {
+		changes["brand_name"] = brand.BrandName
+	}
+	if brand.Slug != current.Slug {
+		changes["slug"] = brand.Slug
+	}
+	if brand.Description != current.Description {
+		changes["description"] = brand.Description
+	}
+	if brand.LogoURL != current.LogoURL {
+		changes["logo_url"] = brand.LogoURL
+	}
+	if brand.Country != current.Country {
+		changes["country"] = brand.Country
+	}
+	if brand.Website != current.Website {
+		changes["website"] = brand.Website
+	}
+	if !brand.SocialLinks.Equal(current.SocialLinks) {
+		changes["social_links"] = brand.SocialLinks
+	}
+	return changes
+}
+
+func (r *brandRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	ctx, span := r.tracer.Start(ctx, "repository.brand.Delete")
 	defer span.End()
 
-	// Check if brand is used in products
+	// Check if brand is used in products that are not in the trash
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&entity.Product{}).Where("id = ?", id).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&entity.Product{}).Where("brand_id = ?", id).Count(&count).Error; err != nil {
 		tracer.RecordError(span, err)
 		return fmt.Errorf("failed to check brand usage: %w", err)
 	}
@@ -126,13 +197,21 @@ func (r *brandRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("cannot delete brand: still has associated products")
 	}
 
-	result := r.db.WithContext(ctx).Delete(&entity.Brand{}, "id = ?", id)
+	// Moves the brand to the trash, Purge removes it for good
+	result := r.db.WithContext(ctx).Delete(&entity.Brand{}, "id = ? AND version = ?", id, version)
 	if result.Error != nil {
 		tracer.RecordError(span, result.Error)
 		return fmt.Errorf("failed to delete brand: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
+		exists, err := r.ExistsByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return entity.ErrVersionMismatch
+		}
 		return fmt.Errorf("brand not found")
 	}
 
@@ -192,3 +271,152 @@ func (r *brandRepository) ExistsByName(ctx context.Context, name string) (bool,
 
 	return exists, nil
 }
+
+func (r *brandRepository) GetBySlug(ctx context.Context, slug string) (*entity.Brand, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.brand.GetBySlug")
+	defer span.End()
+
+	var brand entity.Brand
+	if err := r.db.WithContext(ctx).First(&brand, "slug = ?", slug).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get brand by slug: %w", err)
+	}
+
+	return &brand, nil
+}
+
+func (r *brandRepository) IsSlugTaken(ctx context.Context, slug string, exceptID uuid.UUID) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.brand.IsSlugTaken")
+	defer span.End()
+
+	taken, err := isSlugTaken(ctx, r.db, "brands", entity.SlugEntityBrand, slug, exceptID)
+	if err != nil {
+		tracer.RecordError(span, err)
+		return false, err
+	}
+
+	return taken, nil
+}
+
+func (r *brandRepository) GetTrashedByID(ctx context.Context, id uuid.UUID) (*entity.Brand, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.brand.GetTrashedByID")
+	defer span.End()
+
+	var brand entity.Brand
+	if err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("deleted_at IS NOT NULL").
+		First(&brand, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get trashed brand: %w", err)
+	}
+
+	return &brand, nil
+}
+
+func (r *brandRepository) GetAllTrashed(ctx context.Context, filter entity.TrashFilterRepository) (brands []entity.Brand, count int64, err error) {
+	ctx, span := r.tracer.Start(ctx, "repository.brand.GetAllTrashed")
+	defer span.End()
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("invalid pagination parameters: limit and offset must be non-negative")
+	}
+
+	query := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&entity.Brand{}).
+		Where("deleted_at IS NOT NULL")
+
+	if filter.Search != "" {
+		query = query.Where("brand_name ILIKE ?", "%"+filter.Search+"%")
+	}
+
+	if err = query.Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to count trashed brands: %w", err)
+	}
+
+	if count > 0 && filter.Offset >= int(count) {
+		return []entity.Brand{}, count, nil
+	}
+
+	if err = query.
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Order("deleted_at DESC").
+		Find(&brands).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to list trashed brands: %w", err)
+	}
+
+	return brands, count, nil
+}
+
+func (r *brandRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "repository.brand.Restore")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&entity.Brand{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to restore brand: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.ErrNotInTrash
+	}
+
+	return nil
+}
+
+// Purge permanently deletes brands trashed before deletedBefore. Brands still referenced by a
+// product, even a trashed one, are kept until that product is purged.
+func (r *brandRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.brand.Purge")
+	defer span.End()
+
+	var purged int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		if err := tx.Unscoped().
+			Model(&entity.Brand{}).
+			Where("deleted_at < ?", deletedBefore).
+			Where("NOT EXISTS (SELECT 1 FROM products WHERE products.brand_id = brands.id)").
+			Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("failed to find brands to purge: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := deleteSlugRedirects(ctx, tx, entity.SlugEntityBrand, ids); err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Delete(&entity.Brand{}, "id IN ?", ids)
+		if result.Error != nil {
+			return fmt.Errorf("failed to purge brands: %w", result.Error)
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return 0, err
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// categoryTreeSQL selects the given category and all of its descendants
+const categoryTreeSQL = `WITH RECURSIVE tree AS (
+	SELECT id FROM categories WHERE id = ?
+	UNION ALL
+	SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+) SELECT id FROM tree`
+
+type categoryRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewCategoryRepository(db *gorm.DB, tracer *tracing.Tracer) entity.CategoryRepository {
+	return &categoryRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+func (r *categoryRepository) Create(ctx context.Context, category *entity.Category) error {
+	ctx, span := r.tracer.Start(ctx, "repository.category.Create")
+	defer span.End()
+
+	if err := r.db.WithContext(ctx).Create(category).Error; err != nil {
+		tracer.RecordError(span, err)
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return nil
+}
+
+func (r *categoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.category.GetByID")
+	defer span.End()
+
+	var category entity.Category
+	if err := r.db.WithContext(ctx).First(&category, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return &category, nil
+}
+
+func (r *categoryRepository) GetAllWithFilter(ctx context.Context, filter entity.CategoryFilterRepository) (categories []entity.Category, count int64, err error) {
+	ctx, span := r.tracer.Start(ctx, "repository.category.GetAllWithFilter")
+	defer span.End()
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("invalid pagination parameters: limit and offset must be non-negative")
+	}
+
+	query := r.db.WithContext(ctx).Model(&entity.Category{})
+
+	// Apply filters
+	if filter.Search != "" {
+		query = query.Where("category_name ILIKE ?", "%"+filter.Search+"%")
+	}
+	if filter.ParentID != uuid.Nil {
+		query = query.Where("parent_id = ?", filter.ParentID)
+	} else if filter.RootOnly {
+		query = query.Where("parent_id IS NULL")
+	}
+
+	// Count total records
+	if err = query.Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to count categories: %w", err)
+	}
+
+	// Check if offset is beyond total count
+	if count > 0 && filter.Offset >= int(count) {
+		return []entity.Category{}, count, nil
+	}
+
+	// Get paginated records
+	if err = query.
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Order("category_name ASC").
+		Find(&categories).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	return categories, count, nil
+}
+
+func (r *categoryRepository) Update(ctx context.Context, category *entity.Category) error {
+	ctx, span := r.tracer.Start(ctx, "repository.category.Update")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Model(category).Updates(map[string]interface{}{
+		"category_name": category.CategoryName,
+		"parent_id":     category.ParentID,
+		"updated_at":    time.Now(),
+	})
+
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to update category: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("category not found")
+	}
+
+	return nil
+}
+
+func (r *categoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "repository.category.Delete")
+	defer span.End()
+
+	// Check if category still has children
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Category{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return fmt.Errorf("failed to check category children: %w", err)
+	}
+
+	if count > 0 {
+		return fmt.Errorf("cannot delete category: still has child categories")
+	}
+
+	result := r.db.WithContext(ctx).Delete(&entity.Category{}, "id = ?", id)
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to delete category: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("category not found")
+	}
+
+	return nil
+}
+
+func (r *categoryRepository) ExistsByID(ctx context.Context, id uuid.UUID) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.category.ExistsByID")
+	defer span.End()
+
+	var exists bool
+	err := r.db.WithContext(ctx).
+		Model(&entity.Category{}).
+		Select("1").
+		Where("id = ?", id).
+		Scan(&exists).Error
+
+	if err != nil {
+		tracer.RecordError(span, err)
+		return false, fmt.Errorf("failed to check category existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *categoryRepository) ExistsByName(ctx context.Context, name string, parentID *uuid.UUID) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.category.ExistsByName")
+	defer span.End()
+
+	// Names only need to be unique among siblings
+	query := r.db.WithContext(ctx).
+		Model(&entity.Category{}).
+		Select("1").
+		Where("category_name = ?", name)
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+
+	var exists bool
+	if err := query.Scan(&exists).Error; err != nil {
+		tracer.RecordError(span, err)
+		return false, fmt.Errorf("failed to check category name existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *categoryRepository) CountByIDs(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.category.CountByIDs")
+	defer span.End()
+
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entity.Category{}).
+		Where("id IN ?", ids).
+		Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return 0, fmt.Errorf("failed to count categories: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *categoryRepository) GetDescendantIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.category.GetDescendantIDs")
+	defer span.End()
+
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).Raw(categoryTreeSQL, id).Scan(&ids).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get category descendants: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type exchangeRateRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewExchangeRateRepository(db *gorm.DB, tracer *tracing.Tracer) entity.ExchangeRateRepository {
+	return &exchangeRateRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+func (r *exchangeRateRepository) Upsert(ctx context.Context, rates []entity.ExchangeRate) error {
+	ctx, span := r.tracer.Start(ctx, "repository.exchangeRate.Upsert")
+	defer span.End()
+
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "base_currency"}, {Name: "quote_currency"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+		}).
+		Create(&rates).Error; err != nil {
+		tracer.RecordError(span, err)
+		return fmt.Errorf("failed to upsert exchange rates: %w", err)
+	}
+
+	return nil
+}
+
+func (r *exchangeRateRepository) GetAll(ctx context.Context) ([]entity.ExchangeRate, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.exchangeRate.GetAll")
+	defer span.End()
+
+	var rates []entity.ExchangeRate
+	if err := r.db.WithContext(ctx).
+		Order("base_currency ASC, quote_currency ASC").
+		Find(&rates).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to list exchange rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+func (r *exchangeRateRepository) Get(ctx context.Context, base, quote string) (*entity.ExchangeRate, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.exchangeRate.Get")
+	defer span.End()
+
+	var rate entity.ExchangeRate
+	if err := r.db.WithContext(ctx).
+		First(&rate, "base_currency = ? AND quote_currency = ?", base, quote).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+
+	return &rate, nil
+}
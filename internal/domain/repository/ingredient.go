@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ingredientRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewIngredientRepository(db *gorm.DB, tracer *tracing.Tracer) entity.IngredientRepository {
+	return &ingredientRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+// orderIngredients keeps the INCI order when ingredients are preloaded with a product
+func orderIngredients(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC")
+}
+
+func (r *ingredientRepository) Create(ctx context.Context, ingredient *entity.Ingredient) error {
+	ctx, span := r.tracer.Start(ctx, "repository.ingredient.Create")
+	defer span.End()
+
+	if err := r.db.WithContext(ctx).Create(ingredient).Error; err != nil {
+		tracer.RecordError(span, err)
+		return fmt.Errorf("failed to create ingredient: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ingredientRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Ingredient, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.ingredient.GetByID")
+	defer span.End()
+
+	var ingredient entity.Ingredient
+	if err := r.db.WithContext(ctx).First(&ingredient, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get ingredient: %w", err)
+	}
+
+	return &ingredient, nil
+}
+
+func (r *ingredientRepository) GetAllWithFilter(ctx context.Context, filter entity.IngredientFilterRepository) (ingredients []entity.Ingredient, count int64, err error) {
+	ctx, span := r.tracer.Start(ctx, "repository.ingredient.GetAllWithFilter")
+	defer span.End()
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("invalid pagination parameters: limit and offset must be non-negative")
+	}
+
+	query := r.db.WithContext(ctx).Model(&entity.Ingredient{})
+
+	// Apply search filter on both the INCI and the common name
+	if filter.Search != "" {
+		query = query.Where("inci_name ILIKE ? OR common_name ILIKE ?", "%"+filter.Search+"%", "%"+filter.Search+"%")
+	}
+
+	// Count total records
+	if err = query.Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to count ingredients: %w", err)
+	}
+
+	// Check if offset is beyond total count
+	if count > 0 && filter.Offset >= int(count) {
+		return []entity.Ingredient{}, count, nil
+	}
+
+	// Get paginated records
+	if err = query.
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Order("inci_name ASC").
+		Find(&ingredients).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to list ingredients: %w", err)
+	}
+
+	return ingredients, count, nil
+}
+
+func (r *ingredientRepository) Update(ctx context.Context, ingredient *entity.Ingredient) error {
+	ctx, span := r.tracer.Start(ctx, "repository.ingredient.Update")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Model(ingredient).Updates(map[string]interface{}{
+		"inci_name":   ingredient.INCIName,
+		"common_name": ingredient.CommonName,
+		"updated_at":  time.Now(),
+	})
+
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to update ingredient: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("ingredient not found")
+	}
+
+	return nil
+}
+
+func (r *ingredientRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "repository.ingredient.Delete")
+	defer span.End()
+
+	// Check if ingredient is listed on products
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.ProductIngredient{}).Where("ingredient_id = ?", id).Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return fmt.Errorf("failed to check ingredient usage: %w", err)
+	}
+
+	if count > 0 {
+		return fmt.Errorf("cannot delete ingredient: still used by products")
+	}
+
+	result := r.db.WithContext(ctx).Delete(&entity.Ingredient{}, "id = ?", id)
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to delete ingredient: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("ingredient not found")
+	}
+
+	return nil
+}
+
+func (r *ingredientRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.ingredient.ExistsByName")
+	defer span.End()
+
+	var exists bool
+	err := r.db.WithContext(ctx).
+		Model(&entity.Ingredient{}).
+		Select("1").
+		Where("LOWER(inci_name) = LOWER(?)", name).
+		Scan(&exists).Error
+
+	if err != nil {
+		tracer.RecordError(span, err)
+		return false, fmt.Errorf("failed to check ingredient name existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *ingredientRepository) CountByIDs(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.ingredient.CountByIDs")
+	defer span.End()
+
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entity.Ingredient{}).
+		Where("id IN ?", ids).
+		Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return 0, fmt.Errorf("failed to count ingredients: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type priceListRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewPriceListRepository(db *gorm.DB, tracer *tracing.Tracer) entity.PriceListRepository {
+	return &priceListRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+func (r *priceListRepository) Upsert(ctx context.Context, item *entity.PriceListItem) error {
+	ctx, span := r.tracer.Start(ctx, "repository.priceList.Upsert")
+	defer span.End()
+
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "product_id"}, {Name: "currency"}},
+			DoUpdates: clause.AssignmentColumns([]string{"amount", "updated_at"}),
+		}).
+		Create(item).Error; err != nil {
+		tracer.RecordError(span, err)
+		return fmt.Errorf("failed to upsert price list item: %w", err)
+	}
+
+	return nil
+}
+
+func (r *priceListRepository) GetAllByProductID(ctx context.Context, productID uuid.UUID) ([]entity.PriceListItem, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.priceList.GetAllByProductID")
+	defer span.End()
+
+	var items []entity.PriceListItem
+	if err := r.db.WithContext(ctx).
+		Where("product_id = ?", productID).
+		Order("currency ASC").
+		Find(&items).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to list price list items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *priceListRepository) GetByProductIDs(ctx context.Context, productIDs []uuid.UUID, currency string) ([]entity.PriceListItem, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.priceList.GetByProductIDs")
+	defer span.End()
+
+	var items []entity.PriceListItem
+	if len(productIDs) == 0 {
+		return items, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Where("product_id IN ? AND currency = ?", productIDs, currency).
+		Find(&items).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to list price list items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *priceListRepository) Delete(ctx context.Context, productID uuid.UUID, currency string) error {
+	ctx, span := r.tracer.Start(ctx, "repository.priceList.Delete")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).
+		Where("product_id = ? AND currency = ?", productID, currency).
+		Delete(&entity.PriceListItem{})
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to delete price list item: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.ErrPriceListItemNotFound
+	}
+
+	return nil
+}
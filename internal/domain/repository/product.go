@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
 	"time"
 )
 
@@ -27,9 +29,34 @@ func (r *productRepository) Create(ctx context.Context, product *entity.Product)
 	ctx, span := r.tracer.Start(ctx, "repository.product.Create")
 	defer span.End()
 
-	if err := r.db.WithContext(ctx).Create(product).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Categories", "Tags", "Components").Create(product).Error; err != nil {
+			if isUniqueViolation(err, productSlugIndex) {
+				return fmt.Errorf("%w: %s", entity.ErrProductSlugTaken, product.Slug)
+			}
+			return fmt.Errorf("failed to create product: %w", err)
+		}
+
+		if err := insertProductCategories(tx, product.ID, categoryIDs(product.Categories)); err != nil {
+			return err
+		}
+		if err := insertProductTags(tx, product.ID, entity.TagIDs(product.Tags)); err != nil {
+			return err
+		}
+		if err := insertBundleComponents(tx, product.ID, product.Components); err != nil {
+			return err
+		}
+		if err := recordPriceChange(ctx, tx, product.ID, product.Price, time.Now()); err != nil {
+			return err
+		}
+
+		return recordStockMovement(ctx, tx, product.ID, product.Quantity, entity.StockMovementNote{
+			Reason: entity.StockReasonInitialStock,
+		})
+	})
+	if err != nil {
 		tracer.RecordError(span, err)
-		return fmt.Errorf("failed to create product: %w", err)
+		return err
 	}
 
 	return nil
@@ -41,7 +68,7 @@ func (r *productRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.
 
 	var product entity.Product
 	if err := r.db.WithContext(ctx).
-		Preload("Brand").
+		Scopes(withDetails).
 		First(&product, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -53,30 +80,59 @@ func (r *productRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.
 	return &product, nil
 }
 
-func (r *productRepository) GetAllWithFilter(ctx context.Context, filter entity.ProductFilterRepository) (products []entity.Product, count int64, err error) {
-	if filter.Limit < 0 || filter.Offset < 0 {
-		return nil, 0, fmt.Errorf("invalid pagination parameters: limit and offset must be non-negative")
+func (r *productRepository) GetBySlug(ctx context.Context, slug string) (*entity.Product, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.GetBySlug")
+	defer span.End()
+
+	var product entity.Product
+	if err := r.db.WithContext(ctx).
+		Scopes(withDetails).
+		First(&product, "slug = ?", slug).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get product by slug: %w", err)
 	}
 
-	query := r.db.WithContext(ctx).Model(&entity.Product{})
+	return &product, nil
+}
 
-	// Apply filters
-	if filter.BrandID != uuid.Nil {
-		query = query.Where("brand_id = ?", filter.BrandID)
-	}
-	if filter.MinPrice > 0 {
-		query = query.Where("price >= ?", filter.MinPrice)
-	}
-	if filter.MaxPrice > 0 {
-		query = query.Where("price <= ?", filter.MaxPrice)
-	}
-	if filter.MinQty > 0 {
-		query = query.Where("quantity >= ?", filter.MinQty)
+func (r *productRepository) IsSlugTaken(ctx context.Context, slug string, exceptID uuid.UUID) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.IsSlugTaken")
+	defer span.End()
+
+	taken, err := isSlugTaken(ctx, r.db, "products", entity.SlugEntityProduct, slug, exceptID)
+	if err != nil {
+		tracer.RecordError(span, err)
+		return false, err
 	}
-	if filter.MaxQty > 0 {
-		query = query.Where("quantity <= ?", filter.MaxQty)
+
+	return taken, nil
+}
+
+// withDetails loads a single product with everything its response shows
+func withDetails(db *gorm.DB) *gorm.DB {
+	return db.
+		Select("products.*, "+reservedQuantitySQL+" AS reserved_quantity").
+		Preload("Brand").
+		Preload("Variants", orderVariants).
+		Preload("Categories").
+		Preload("Tags", orderTags).
+		Preload("Ingredients", orderIngredients).
+		Preload("Ingredients.Ingredient").
+		Preload("Images", orderImages).
+		Preload("Components").
+		Preload("Components.Component", withReservedQuantity)
+}
+
+func (r *productRepository) GetAllWithFilter(ctx context.Context, filter entity.ProductFilterRepository) (products []entity.Product, count int64, err error) {
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("invalid pagination parameters: limit and offset must be non-negative")
 	}
 
+	query := applyProductFilter(r.db.WithContext(ctx).Model(&entity.Product{}), filter)
+
 	// Count total records
 	if err = query.Count(&count).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to count products: %w", err)
@@ -87,9 +143,30 @@ func (r *productRepository) GetAllWithFilter(ctx context.Context, filter entity.
 		return []entity.Product{}, count, nil
 	}
 
+	if search := searchQuery(filter.Query); search != "" {
+		// The best matches come first, each with the fragments that matched
+		query = query.
+			Select("products.*, "+reservedQuantitySQL+" AS reserved_quantity, "+productHighlightSQL,
+				search, headlineNameOptions, search, headlineNameOptions, search, headlineTextOptions).
+			Order(clause.OrderBy{Expression: clause.Expr{
+				SQL:  "ts_rank(products.search_vector, " + tsQuerySQL + ") DESC",
+				Vars: []interface{}{search},
+			}})
+	} else {
+		query = query.Select("products.*, " + reservedQuantitySQL + " AS reserved_quantity")
+	}
+
 	// Get paginated records
 	if err = query.
 		Preload("Brand").
+		Preload("Variants", orderVariants).
+		Preload("Categories").
+		Preload("Tags", orderTags).
+		Preload("Ingredients", orderIngredients).
+		Preload("Ingredients.Ingredient").
+		Preload("Images", orderImages).
+		Preload("Components").
+		Preload("Components.Component", withReservedQuantity).
 		Limit(filter.Limit).
 		Offset(filter.Offset).
 		Order("created_at DESC").
@@ -100,41 +177,211 @@ func (r *productRepository) GetAllWithFilter(ctx context.Context, filter entity.
 	return products, count, nil
 }
 
+// stockQuantitySQL is the stock of a product, for a bundle the number of complete bundles the stock
+// of its components makes, the same as Product.TotalStock
+const stockQuantitySQL = `CASE WHEN products.product_type = 'bundle' THEN COALESCE((
+		SELECT MIN(CASE WHEN c.id IS NULL OR bc.quantity <= 0 THEN 0 ELSE c.quantity / bc.quantity END)
+		FROM bundle_components bc LEFT JOIN products c ON c.id = bc.component_id AND c.deleted_at IS NULL
+		WHERE bc.bundle_id = products.id), 0)
+	ELSE products.quantity END`
+
+// applyProductFilter narrows a product query to the products matching the list filters
+func applyProductFilter(query *gorm.DB, filter entity.ProductFilterRepository) *gorm.DB {
+	if search := searchQuery(filter.Query); search != "" {
+		query = query.Where("products.search_vector @@ "+tsQuerySQL, search)
+	}
+	if filter.BrandID != uuid.Nil {
+		query = query.Where("brand_id = ?", filter.BrandID)
+	}
+	if filter.CategoryID != uuid.Nil {
+		if filter.IncludeDescendants {
+			query = query.Where("id IN (SELECT product_id FROM product_categories WHERE category_id IN ("+categoryTreeSQL+"))", filter.CategoryID)
+		} else {
+			query = query.Where("id IN (SELECT product_id FROM product_categories WHERE category_id = ?)", filter.CategoryID)
+		}
+	}
+	if len(filter.Tags) > 0 {
+		if filter.TagMode == entity.TagModeAll {
+			query = query.Where(`id IN (SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+				WHERE t.tag_name IN ? GROUP BY pt.product_id HAVING COUNT(DISTINCT t.id) = ?)`, filter.Tags, len(filter.Tags))
+		} else {
+			query = query.Where(`id IN (SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+				WHERE t.tag_name IN ?)`, filter.Tags)
+		}
+	}
+	// Every included ingredient must be listed, matched on INCI or common name
+	for _, name := range filter.IncludeIngredients {
+		query = query.Where(`id IN (SELECT pi.product_id FROM product_ingredients pi JOIN ingredients i ON i.id = pi.ingredient_id
+			WHERE LOWER(i.inci_name) = ? OR LOWER(i.common_name) = ?)`, strings.ToLower(name), strings.ToLower(name))
+	}
+	if len(filter.ExcludeIngredients) > 0 {
+		names := make([]string, len(filter.ExcludeIngredients))
+		for i, name := range filter.ExcludeIngredients {
+			names[i] = strings.ToLower(name)
+		}
+		query = query.Where(`id NOT IN (SELECT pi.product_id FROM product_ingredients pi JOIN ingredients i ON i.id = pi.ingredient_id
+			WHERE LOWER(i.inci_name) IN ? OR LOWER(i.common_name) IN ?)`, names, names)
+	}
+	if filter.MinPrice.IsPositive() {
+		query = query.Where("price_currency = ? AND price_amount >= ?", filter.MinPrice.Currency, filter.MinPrice.Amount)
+	}
+	if filter.MaxPrice.IsPositive() {
+		query = query.Where("price_currency = ? AND price_amount <= ?", filter.MaxPrice.Currency, filter.MaxPrice.Amount)
+	}
+	// Bundles hold no stock of their own, they are filtered on the stock of their components
+	if filter.MinQty > 0 {
+		query = query.Where(stockQuantitySQL+" >= ?", filter.MinQty)
+	}
+	if filter.MaxQty > 0 {
+		query = query.Where(stockQuantitySQL+" <= ?", filter.MaxQty)
+	}
+	if filter.ProductType != "" {
+		query = query.Where("product_type = ?", filter.ProductType)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	return query
+}
+
 func (r *productRepository) Update(ctx context.Context, product *entity.Product) error {
 	ctx, span := r.tracer.Start(ctx, "repository.product.Update")
 	defer span.End()
 
-	result := r.db.WithContext(ctx).Model(product).Updates(map[string]interface{}{
-		"product_name": product.ProductName,
-		"price":        product.Price,
-		"quantity":     product.Quantity,
-		"brand_id":     product.BrandID,
-		"updated_at":   time.Now(),
+	var updatedAt time.Time
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		if updatedAt, err = updateProduct(ctx, tx, product); err != nil {
+			return err
+		}
+
+		// Categories and tags are replaced wholesale with the ones the product carries
+		if err := tx.Where("product_id = ?", product.ID).Delete(&entity.ProductCategory{}).Error; err != nil {
+			return fmt.Errorf("failed to clear product categories: %w", err)
+		}
+		if err := insertProductCategories(tx, product.ID, categoryIDs(product.Categories)); err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", product.ID).Delete(&entity.ProductTag{}).Error; err != nil {
+			return fmt.Errorf("failed to clear product tags: %w", err)
+		}
+		return insertProductTags(tx, product.ID, entity.TagIDs(product.Tags))
 	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
 
-	if result.Error != nil {
-		tracer.RecordError(span, result.Error)
-		return fmt.Errorf("failed to update product: %w", result.Error)
+	product.Version++
+	product.UpdatedAt = updatedAt
+	return nil
+}
+
+// updateProduct writes the changed columns of a product read at product.Version and returns the
+// time it was updated at. The caller bumps the version once the transaction commits.
+func updateProduct(ctx context.Context, tx *gorm.DB, product *entity.Product) (time.Time, error) {
+	// Lock the row so the changed columns, ledger delta, price history and slug redirect follow
+	// the values we overwrite
+	var current entity.Product
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "product_name", "slug", "quantity", "price_amount", "price_currency", "brand_id", "version").
+		First(&current, "id = ?", product.ID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return time.Time{}, fmt.Errorf("product not found")
+		}
+		return time.Time{}, fmt.Errorf("failed to update product: %w", err)
+	}
+
+	// The lock keeps new reservations out, so the units they hold cannot grow past the new quantity
+	if product.Quantity < current.Quantity {
+		var reserved int
+		if err := tx.Model(&entity.Reservation{}).
+			Select("COALESCE(SUM(quantity), 0)").
+			Where("product_id = ? AND status = ? AND expires_at > NOW()", product.ID, entity.ReservationStatusPending).
+			Scan(&reserved).Error; err != nil {
+			return time.Time{}, fmt.Errorf("failed to sum reserved quantity: %w", err)
+		}
+		if product.Quantity < reserved {
+			return time.Time{}, fmt.Errorf("%w: %d unit(s) are reserved", entity.ErrInsufficientStock, reserved)
+		}
 	}
 
+	// The product carries the version it was read at, another write since then bumped it
+	now := time.Now()
+	changes := productChanges(&current, product)
+	changes["version"] = gorm.Expr("version + 1")
+	changes["updated_at"] = now
+
+	result := tx.Model(&entity.Product{}).Where("id = ? AND version = ?", product.ID, product.Version).Updates(changes)
+	if result.Error != nil {
+		if isUniqueViolation(result.Error, productSlugIndex) {
+			return time.Time{}, fmt.Errorf("%w: %s", entity.ErrProductSlugTaken, product.Slug)
+		}
+		return time.Time{}, fmt.Errorf("failed to update product: %w", result.Error)
+	}
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("product not found")
+		return time.Time{}, fmt.Errorf("%w: product is at version %d", entity.ErrVersionMismatch, current.Version)
 	}
 
-	return nil
+	if current.Price != product.Price {
+		if err := recordPriceChange(ctx, tx, product.ID, product.Price, now); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	if current.Slug != product.Slug {
+		if err := moveSlug(ctx, tx, entity.SlugEntityProduct, product.ID, current.Slug, product.Slug); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	err := recordStockMovement(ctx, tx, product.ID, product.Quantity-current.Quantity, entity.StockMovementNote{
+		Reason: entity.StockReasonProductUpdate,
+	})
+	return now, err
+}
+
+// productChanges maps the columns whose value differs from the stored product to their new value
+func productChanges(current, product *entity.Product) map[string]interface{} {
+	changes := map[string]interface{}{}
+	if product.ProductName != current.ProductName {
+		changes["product_name"] = product.ProductName
+	}
+	if product.Slug != current.Slug {
+		changes["slug"] = product.Slug
+	}
+	if product.Price != current.Price {
+		changes["price_amount"] = product.Price.Amount
+		changes["price_currency"] = product.Price.Currency
+	}
+	if product.Quantity != current.Quantity {
+		changes["quantity"] = product.Quantity
+	}
+	if product.BrandID != current.BrandID {
+		changes["brand_id"] = product.BrandID
+	}
+	return changes
 }
 
-func (r *productRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (r *productRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	ctx, span := r.tracer.Start(ctx, "repository.product.Delete")
 	defer span.End()
 
-	result := r.db.WithContext(ctx).Delete(&entity.Product{}, "id = ?", id)
+	// Moves the product to the trash, Purge removes it for good
+	result := r.db.WithContext(ctx).Delete(&entity.Product{}, "id = ? AND version = ?", id, version)
 	if result.Error != nil {
 		tracer.RecordError(span, result.Error)
 		return fmt.Errorf("failed to delete product: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
+		exists, err := r.ExistsByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return entity.ErrVersionMismatch
+		}
 		return fmt.Errorf("product not found")
 	}
 
@@ -166,7 +413,16 @@ func (r *productRepository) GetByName(ctx context.Context, name string) (*entity
 
 	var product entity.Product
 	if err := r.db.WithContext(ctx).
+		Select("products.*, "+reservedQuantitySQL+" AS reserved_quantity").
 		Preload("Brand").
+		Preload("Variants", orderVariants).
+		Preload("Categories").
+		Preload("Tags", orderTags).
+		Preload("Ingredients", orderIngredients).
+		Preload("Ingredients.Ingredient").
+		Preload("Images", orderImages).
+		Preload("Components").
+		Preload("Components.Component", withReservedQuantity).
 		First(&product, "product_name = ?", name).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -196,3 +452,438 @@ func (r *productRepository) ExistsByName(ctx context.Context, name string) (bool
 
 	return exists, nil
 }
+
+func insertProductCategories(tx *gorm.DB, productID uuid.UUID, categoryIDs []uuid.UUID) error {
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	rows := make([]entity.ProductCategory, len(categoryIDs))
+	for i, categoryID := range categoryIDs {
+		rows[i] = entity.ProductCategory{ProductID: productID, CategoryID: categoryID}
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to assign product categories: %w", err)
+	}
+
+	return nil
+}
+
+func categoryIDs(categories []entity.Category) []uuid.UUID {
+	ids := make([]uuid.UUID, len(categories))
+	for i, category := range categories {
+		ids[i] = category.ID
+	}
+	return ids
+}
+
+func insertProductTags(tx *gorm.DB, productID uuid.UUID, tagIDs []uuid.UUID) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	rows := make([]entity.ProductTag, len(tagIDs))
+	for i, tagID := range tagIDs {
+		rows[i] = entity.ProductTag{ProductID: productID, TagID: tagID}
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to assign product tags: %w", err)
+	}
+
+	return nil
+}
+
+func (r *productRepository) ReplaceIngredients(ctx context.Context, id uuid.UUID, ingredientIDs []uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "repository.product.ReplaceIngredients")
+	defer span.End()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", id).Delete(&entity.ProductIngredient{}).Error; err != nil {
+			return fmt.Errorf("failed to clear product ingredients: %w", err)
+		}
+
+		if len(ingredientIDs) == 0 {
+			return nil
+		}
+
+		// Positions follow the order of the list, starting at 1
+		rows := make([]entity.ProductIngredient, len(ingredientIDs))
+		for i, ingredientID := range ingredientIDs {
+			rows[i] = entity.ProductIngredient{ProductID: id, IngredientID: ingredientID, Position: i + 1}
+		}
+		if err := tx.Omit("Ingredient").Create(&rows).Error; err != nil {
+			return fmt.Errorf("failed to assign product ingredients: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) IncreaseStock(ctx context.Context, id uuid.UUID, amount int, note entity.StockMovementNote) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.IncreaseStock")
+	defer span.End()
+
+	var product entity.Product
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&product).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "quantity"}}}).
+			Where("id = ? AND product_type = ?", id, entity.ProductTypeSimple).
+			Updates(map[string]interface{}{
+				"quantity":   gorm.Expr("quantity + ?", amount),
+				"version":    gorm.Expr("version + 1"),
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to increase product stock: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			var productType string
+			if err := tx.Model(&entity.Product{}).Select("product_type").Where("id = ?", id).Scan(&productType).Error; err != nil {
+				return fmt.Errorf("failed to check product existence: %w", err)
+			}
+			if productType == entity.ProductTypeBundle {
+				return entity.ErrBundleStockDerived
+			}
+			return fmt.Errorf("product not found")
+		}
+
+		return recordStockMovement(ctx, tx, id, amount, note)
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return 0, err
+	}
+
+	return product.Quantity, nil
+}
+
+func (r *productRepository) DecreaseStock(ctx context.Context, id uuid.UUID, amount int, note entity.StockMovementNote) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.DecreaseStock")
+	defer span.End()
+
+	var product entity.Product
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var components []entity.BundleComponent
+		if err := tx.Where("bundle_id = ?", id).Order("component_id").Find(&components).Error; err != nil {
+			return fmt.Errorf("failed to get bundle components: %w", err)
+		}
+		if len(components) > 0 {
+			return decreaseBundleStock(ctx, tx, id, components, amount, note)
+		}
+
+		// Single conditional update so concurrent orders can never eat into stock held by reservations
+		result := tx.Model(&product).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "quantity"}}}).
+			Where("id = ? AND quantity - "+reservedQuantitySQL+" >= ?", id, amount).
+			Updates(map[string]interface{}{
+				"quantity":   gorm.Expr("quantity - ?", amount),
+				"version":    gorm.Expr("version + 1"),
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to decrease product stock: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			var exists bool
+			if err := tx.Model(&entity.Product{}).Select("1").Where("id = ?", id).Scan(&exists).Error; err != nil {
+				return fmt.Errorf("failed to check product existence: %w", err)
+			}
+			if !exists {
+				return fmt.Errorf("product not found")
+			}
+			return entity.ErrInsufficientStock
+		}
+
+		return recordStockMovement(ctx, tx, id, -amount, note)
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return 0, err
+	}
+
+	return product.Quantity, nil
+}
+
+// decreaseBundleStock sells amount bundles by taking every component out of stock in the same
+// transaction. Components are updated in component_id order so concurrent sales of bundles
+// sharing products lock them in the same order
+func decreaseBundleStock(ctx context.Context, tx *gorm.DB, bundleID uuid.UUID, components []entity.BundleComponent, amount int, note entity.StockMovementNote) error {
+	// A trashed bundle keeps its components, hold the live row so it cannot be sold or trashed meanwhile
+	var bundle entity.Product
+	result := tx.Clauses(clause.Locking{Strength: "SHARE"}).
+		Select("id").
+		Where("id = ?", bundleID).
+		Limit(1).
+		Find(&bundle)
+	if result.Error != nil {
+		return fmt.Errorf("failed to get bundle: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("product not found")
+	}
+
+	if note.Reference == "" {
+		note.Reference = bundleID.String()
+	}
+
+	for _, component := range components {
+		units := component.Quantity * amount
+		result := tx.Model(&entity.Product{}).
+			Where("id = ? AND quantity - "+reservedQuantitySQL+" >= ?", component.ComponentID, units).
+			Updates(map[string]interface{}{
+				"quantity":   gorm.Expr("quantity - ?", units),
+				"version":    gorm.Expr("version + 1"),
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to decrease bundle component stock: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: bundle component %s", entity.ErrInsufficientStock, component.ComponentID)
+		}
+
+		if err := recordStockMovement(ctx, tx, component.ComponentID, -units, note); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withReservedQuantity loads products together with the units held by their active reservations
+func withReservedQuantity(db *gorm.DB) *gorm.DB {
+	return db.Select("products.*, " + reservedQuantitySQL + " AS reserved_quantity")
+}
+
+func (r *productRepository) ReplaceBundleComponents(ctx context.Context, id uuid.UUID, components []entity.BundleComponent) error {
+	ctx, span := r.tracer.Start(ctx, "repository.product.ReplaceBundleComponents")
+	defer span.End()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bundle_id = ?", id).Delete(&entity.BundleComponent{}).Error; err != nil {
+			return fmt.Errorf("failed to clear bundle components: %w", err)
+		}
+
+		return insertBundleComponents(tx, id, components)
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func insertBundleComponents(tx *gorm.DB, bundleID uuid.UUID, components []entity.BundleComponent) error {
+	if len(components) == 0 {
+		return nil
+	}
+
+	rows := make([]entity.BundleComponent, len(components))
+	for i, component := range components {
+		rows[i] = entity.BundleComponent{BundleID: bundleID, ComponentID: component.ComponentID, Quantity: component.Quantity}
+	}
+	if err := tx.Omit("Component").Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to assign bundle components: %w", err)
+	}
+
+	return nil
+}
+
+func (r *productRepository) CountSimpleByIDs(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.CountSimpleByIDs")
+	defer span.End()
+
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entity.Product{}).
+		Where("id IN ? AND product_type = ?", ids, entity.ProductTypeSimple).
+		Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return 0, fmt.Errorf("failed to count products: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *productRepository) IsBundleComponent(ctx context.Context, id uuid.UUID) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.IsBundleComponent")
+	defer span.End()
+
+	var exists bool
+	err := r.db.WithContext(ctx).
+		Model(&entity.BundleComponent{}).
+		Select("1").
+		Where("component_id = ?", id).
+		Limit(1).
+		Scan(&exists).Error
+
+	if err != nil {
+		tracer.RecordError(span, err)
+		return false, fmt.Errorf("failed to check bundle component existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// UpdateStatus moves the product to status to, provided it is still in status from
+func (r *productRepository) UpdateStatus(ctx context.Context, id uuid.UUID, from, to string) error {
+	ctx, span := r.tracer.Start(ctx, "repository.product.UpdateStatus")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).
+		Model(&entity.Product{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(map[string]interface{}{
+			"status":     to,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to update product status: %w", result.Error)
+	}
+
+	// Another request changed the status since it was read
+	if result.RowsAffected == 0 {
+		return entity.ErrInvalidStatusTransition
+	}
+
+	return nil
+}
+
+func (r *productRepository) GetTrashedByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.GetTrashedByID")
+	defer span.End()
+
+	var product entity.Product
+	if err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("deleted_at IS NOT NULL").
+		First(&product, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get trashed product: %w", err)
+	}
+
+	return &product, nil
+}
+
+func (r *productRepository) GetAllTrashed(ctx context.Context, filter entity.TrashFilterRepository) (products []entity.Product, count int64, err error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.GetAllTrashed")
+	defer span.End()
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("invalid pagination parameters: limit and offset must be non-negative")
+	}
+
+	query := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&entity.Product{}).
+		Where("deleted_at IS NOT NULL")
+
+	if filter.Search != "" {
+		query = query.Where("product_name ILIKE ?", "%"+filter.Search+"%")
+	}
+
+	if err = query.Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to count trashed products: %w", err)
+	}
+
+	if count > 0 && filter.Offset >= int(count) {
+		return []entity.Product{}, count, nil
+	}
+
+	// The brand may be in the trash as well
+	if err = query.
+		Preload("Brand", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Order("deleted_at DESC").
+		Find(&products).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to list trashed products: %w", err)
+	}
+
+	return products, count, nil
+}
+
+func (r *productRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "repository.product.Restore")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&entity.Product{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to restore product: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.ErrNotInTrash
+	}
+
+	return nil
+}
+
+// Purge permanently deletes products trashed before deletedBefore, along with their stock
+// ledger, variants and other rows that cascade. Products still used in a bundle are kept.
+func (r *productRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, []string, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.Purge")
+	defer span.End()
+
+	var purged int64
+	var imageKeys []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		if err := tx.Unscoped().
+			Model(&entity.Product{}).
+			Where("deleted_at < ?", deletedBefore).
+			Where("NOT EXISTS (SELECT 1 FROM bundle_components bc WHERE bc.component_id = products.id)").
+			Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("failed to find products to purge: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := deleteSlugRedirects(ctx, tx, entity.SlugEntityProduct, ids); err != nil {
+			return err
+		}
+
+		// The image rows cascade with the products, their stored files do not
+		if err := tx.Model(&entity.ProductImage{}).
+			Where("product_id IN ?", ids).
+			Pluck("storage_key", &imageKeys).Error; err != nil {
+			return fmt.Errorf("failed to get product images to purge: %w", err)
+		}
+
+		result := tx.Unscoped().Delete(&entity.Product{}, "id IN ?", ids)
+		if result.Error != nil {
+			return fmt.Errorf("failed to purge products: %w", result.Error)
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return 0, nil, err
+	}
+
+	return purged, imageKeys, nil
+}
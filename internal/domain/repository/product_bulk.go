@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/pkg/actor"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// bulkInsertSize bounds the rows of a single INSERT statement of a batch write
+const bulkInsertSize = 100
+
+func (r *productRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]entity.Product, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.GetByIDs")
+	defer span.End()
+
+	var products []entity.Product
+	if len(ids) == 0 {
+		return products, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Scopes(withDetails).
+		Find(&products, "products.id IN ?", ids).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+
+	return products, nil
+}
+
+func (r *productRepository) ExistingNames(ctx context.Context, names []string) ([]string, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.ExistingNames")
+	defer span.End()
+
+	var existing []string
+	if len(names) == 0 {
+		return existing, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&entity.Product{}).
+		Where("product_name IN ?", names).
+		Pluck("product_name", &existing).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to check product name existence: %w", err)
+	}
+
+	return existing, nil
+}
+
+func (r *productRepository) GetVersionsByNames(ctx context.Context, names []string) ([]entity.Product, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.GetVersionsByNames")
+	defer span.End()
+
+	var products []entity.Product
+	if len(names) == 0 {
+		return products, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Select("id", "product_name", "version").
+		Find(&products, "product_name IN ?", names).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get products by name: %w", err)
+	}
+
+	return products, nil
+}
+
+func (r *productRepository) TakenSlugs(ctx context.Context, slugs []string) ([]string, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.TakenSlugs")
+	defer span.End()
+
+	var taken []string
+	if len(slugs) == 0 {
+		return taken, nil
+	}
+
+	// Slugs kept as redirects stay reserved, the same as isSlugTaken
+	if err := r.db.WithContext(ctx).Raw(
+		"SELECT slug FROM products WHERE slug IN ? "+
+			"UNION SELECT slug FROM slug_redirects WHERE entity_type = ? AND slug IN ?",
+		slugs, entity.SlugEntityProduct, slugs,
+	).Scan(&taken).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to check slug availability: %w", err)
+	}
+
+	return taken, nil
+}
+
+func (r *productRepository) FilterBundleComponents(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.product.FilterBundleComponents")
+	defer span.End()
+
+	var components []uuid.UUID
+	if len(ids) == 0 {
+		return components, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&entity.BundleComponent{}).
+		Distinct("component_id").
+		Where("component_id IN ?", ids).
+		Pluck("component_id", &components).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to check bundle component existence: %w", err)
+	}
+
+	return components, nil
+}
+
+func (r *productRepository) CreateBatch(ctx context.Context, products []*entity.Product) error {
+	ctx, span := r.tracer.Start(ctx, "repository.product.CreateBatch")
+	defer span.End()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Categories", "Tags", "Components").CreateInBatches(products, bulkInsertSize).Error; err != nil {
+			return fmt.Errorf("failed to create products: %w", err)
+		}
+
+		now := time.Now()
+		by := actor.FromContext(ctx)
+		var (
+			categories []entity.ProductCategory
+			tags       []entity.ProductTag
+			prices     []entity.ProductPrice
+			movements  []entity.StockMovement
+		)
+		for _, product := range products {
+			for _, category := range product.Categories {
+				categories = append(categories, entity.ProductCategory{ProductID: product.ID, CategoryID: category.ID})
+			}
+			for _, tag := range product.Tags {
+				tags = append(tags, entity.ProductTag{ProductID: product.ID, TagID: tag.ID})
+			}
+			prices = append(prices, entity.ProductPrice{
+				ProductID:     product.ID,
+				Price:         product.Price,
+				EffectiveFrom: now,
+				AppliedAt:     &now,
+				Actor:         by,
+			})
+			if product.Quantity != 0 {
+				movements = append(movements, entity.StockMovement{
+					ProductID: product.ID,
+					Delta:     product.Quantity,
+					Reason:    entity.StockReasonInitialStock,
+					Actor:     by,
+				})
+			}
+		}
+
+		return insertBatchRows(tx, categories, tags, prices, movements)
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) UpdateBatch(ctx context.Context, products []*entity.Product) error {
+	ctx, span := r.tracer.Start(ctx, "repository.product.UpdateBatch")
+	defer span.End()
+
+	updatedAt := make([]time.Time, len(products))
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids := make([]uuid.UUID, len(products))
+		var (
+			categories []entity.ProductCategory
+			tags       []entity.ProductTag
+		)
+		for i, product := range products {
+			at, err := updateProduct(ctx, tx, product)
+			if err != nil {
+				return &entity.BatchItemError{Index: i, Err: err}
+			}
+			updatedAt[i] = at
+			ids[i] = product.ID
+
+			for _, category := range product.Categories {
+				categories = append(categories, entity.ProductCategory{ProductID: product.ID, CategoryID: category.ID})
+			}
+			for _, tag := range product.Tags {
+				tags = append(tags, entity.ProductTag{ProductID: product.ID, TagID: tag.ID})
+			}
+		}
+
+		// Categories and tags are replaced wholesale with the ones each product carries
+		if err := tx.Where("product_id IN ?", ids).Delete(&entity.ProductCategory{}).Error; err != nil {
+			return fmt.Errorf("failed to clear product categories: %w", err)
+		}
+		if err := tx.Where("product_id IN ?", ids).Delete(&entity.ProductTag{}).Error; err != nil {
+			return fmt.Errorf("failed to clear product tags: %w", err)
+		}
+
+		return insertBatchRows(tx, categories, tags, nil, nil)
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	for i, product := range products {
+		product.Version++
+		product.UpdatedAt = updatedAt[i]
+	}
+	return nil
+}
+
+func (r *productRepository) DeleteBatch(ctx context.Context, products []*entity.Product) error {
+	ctx, span := r.tracer.Start(ctx, "repository.product.DeleteBatch")
+	defer span.End()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, product := range products {
+			result := tx.Delete(&entity.Product{}, "id = ? AND version = ?", product.ID, product.Version)
+			if result.Error != nil {
+				return fmt.Errorf("failed to delete product: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return &entity.BatchItemError{Index: i, Err: entity.ErrVersionMismatch}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// insertBatchRows writes the association and ledger rows of a batch write in batched statements
+func insertBatchRows(tx *gorm.DB, categories []entity.ProductCategory, tags []entity.ProductTag, prices []entity.ProductPrice, movements []entity.StockMovement) error {
+	if len(categories) > 0 {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&categories, bulkInsertSize).Error; err != nil {
+			return fmt.Errorf("failed to assign product categories: %w", err)
+		}
+	}
+	if len(tags) > 0 {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&tags, bulkInsertSize).Error; err != nil {
+			return fmt.Errorf("failed to assign product tags: %w", err)
+		}
+	}
+	if len(prices) > 0 {
+		if err := tx.CreateInBatches(&prices, bulkInsertSize).Error; err != nil {
+			return fmt.Errorf("failed to record price change: %w", err)
+		}
+	}
+	if len(movements) > 0 {
+		if err := tx.CreateInBatches(&movements, bulkInsertSize).Error; err != nil {
+			return fmt.Errorf("failed to record stock movement: %w", err)
+		}
+	}
+	return nil
+}
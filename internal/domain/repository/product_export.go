@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// productExportSQL selects a product flattened to an export row, its categories and tags
+// aggregated in place so every product is a single row of the result
+const productExportSQL = `products.id, products.product_name, products.slug, products.product_type, products.status,
+	products.price_amount, products.price_currency, products.quantity, products.brand_id,
+	(SELECT b.brand_name FROM brands b WHERE b.id = products.brand_id) AS brand_name,
+	(SELECT COALESCE(json_agg(pc.category_id ORDER BY pc.category_id), '[]') FROM product_categories pc
+		WHERE pc.product_id = products.id) AS category_ids,
+	(SELECT COALESCE(json_agg(t.tag_name ORDER BY t.tag_name), '[]') FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+		WHERE pt.product_id = products.id) AS tags,
+	products.version, products.created_at, products.updated_at`
+
+func (r *productRepository) StreamWithFilter(ctx context.Context, filter entity.ProductFilterRepository, fn func(row *entity.ProductExportRow) error) error {
+	ctx, span := r.tracer.Start(ctx, "repository.product.StreamWithFilter")
+	defer span.End()
+
+	// The rows are read off the connection as fn consumes them, the limit and offset of the
+	// filter do not apply
+	rows, err := applyProductFilter(r.db.WithContext(ctx).Model(&entity.Product{}), filter).
+		Select(productExportSQL).
+		Order("created_at DESC, id").
+		Rows()
+	if err != nil {
+		tracer.RecordError(span, err)
+		return fmt.Errorf("failed to export products: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			row         entity.ProductExportRow
+			brandName   *string
+			categoryIDs []byte
+			tags        []byte
+		)
+		if err := rows.Scan(
+			&row.ID, &row.ProductName, &row.Slug, &row.ProductType, &row.Status,
+			&row.Price.Amount, &row.Price.Currency, &row.Quantity, &row.BrandID,
+			&brandName, &categoryIDs, &tags,
+			&row.Version, &row.CreatedAt, &row.UpdatedAt,
+		); err != nil {
+			tracer.RecordError(span, err)
+			return fmt.Errorf("failed to read exported product: %w", err)
+		}
+		if brandName != nil {
+			row.BrandName = *brandName
+		}
+		if err := json.Unmarshal(categoryIDs, &row.CategoryIDs); err != nil {
+			tracer.RecordError(span, err)
+			return fmt.Errorf("failed to read exported product categories: %w", err)
+		}
+		if err := json.Unmarshal(tags, &row.Tags); err != nil {
+			tracer.RecordError(span, err)
+			return fmt.Errorf("failed to read exported product tags: %w", err)
+		}
+
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		tracer.RecordError(span, err)
+		return fmt.Errorf("failed to export products: %w", err)
+	}
+	return nil
+}
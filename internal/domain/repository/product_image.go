@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type productImageRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewProductImageRepository(db *gorm.DB, tracer *tracing.Tracer) entity.ProductImageRepository {
+	return &productImageRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+// orderImages returns images in display order when preloaded with a product
+func orderImages(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC")
+}
+
+func (r *productImageRepository) Create(ctx context.Context, image *entity.ProductImage) error {
+	ctx, span := r.tracer.Start(ctx, "repository.productImage.Create")
+	defer span.End()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, image.ProductID); err != nil {
+			return err
+		}
+
+		var last struct {
+			Count    int64
+			Position int
+		}
+		if err := tx.Model(&entity.ProductImage{}).
+			Select("COUNT(*) AS count, COALESCE(MAX(position), 0) AS position").
+			Where("product_id = ?", image.ProductID).
+			Scan(&last).Error; err != nil {
+			return fmt.Errorf("failed to get image position: %w", err)
+		}
+
+		// New images go last, and the first image of a product is always its primary one
+		image.Position = last.Position + 1
+		if last.Count == 0 {
+			image.IsPrimary = true
+		}
+
+		if image.IsPrimary {
+			if err := clearPrimaryImage(tx, image.ProductID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(image).Error; err != nil {
+			return fmt.Errorf("failed to create product image: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *productImageRepository) GetByID(ctx context.Context, productID, id uuid.UUID) (*entity.ProductImage, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.productImage.GetByID")
+	defer span.End()
+
+	var image entity.ProductImage
+	if err := r.db.WithContext(ctx).
+		First(&image, "id = ? AND product_id = ?", id, productID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get product image: %w", err)
+	}
+
+	return &image, nil
+}
+
+func (r *productImageRepository) GetAllByProductID(ctx context.Context, productID uuid.UUID) ([]entity.ProductImage, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.productImage.GetAllByProductID")
+	defer span.End()
+
+	var images []entity.ProductImage
+	if err := orderImages(r.db.WithContext(ctx)).
+		Where("product_id = ?", productID).
+		Find(&images).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to list product images: %w", err)
+	}
+
+	return images, nil
+}
+
+func (r *productImageRepository) Delete(ctx context.Context, productID, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "repository.productImage.Delete")
+	defer span.End()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, productID); err != nil {
+			return err
+		}
+
+		var image entity.ProductImage
+		if err := tx.First(&image, "id = ? AND product_id = ?", id, productID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return entity.ErrProductImageNotFound
+			}
+			return fmt.Errorf("failed to get product image: %w", err)
+		}
+
+		if err := tx.Delete(&image).Error; err != nil {
+			return fmt.Errorf("failed to delete product image: %w", err)
+		}
+
+		if !image.IsPrimary {
+			return nil
+		}
+
+		// Promote the next image so a product with images always has a primary one
+		var next entity.ProductImage
+		if err := orderImages(tx).First(&next, "product_id = ?", productID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			}
+			return fmt.Errorf("failed to get next product image: %w", err)
+		}
+
+		if err := tx.Model(&next).Updates(map[string]interface{}{
+			"is_primary": true,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+			return fmt.Errorf("failed to promote product image: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *productImageRepository) Reorder(ctx context.Context, productID uuid.UUID, imageIDs []uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "repository.productImage.Reorder")
+	defer span.End()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, productID); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&entity.ProductImage{}).
+			Where("product_id = ? AND id IN ?", productID, imageIDs).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count product images: %w", err)
+		}
+
+		var total int64
+		if err := tx.Model(&entity.ProductImage{}).
+			Where("product_id = ?", productID).
+			Count(&total).Error; err != nil {
+			return fmt.Errorf("failed to count product images: %w", err)
+		}
+
+		if count != int64(len(imageIDs)) || total != count {
+			return entity.ErrImageOrderMismatch
+		}
+
+		now := time.Now()
+		for i, imageID := range imageIDs {
+			if err := tx.Model(&entity.ProductImage{}).
+				Where("id = ?", imageID).
+				Updates(map[string]interface{}{
+					"position":   i + 1,
+					"updated_at": now,
+				}).Error; err != nil {
+				return fmt.Errorf("failed to reorder product images: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *productImageRepository) SetPrimary(ctx context.Context, productID, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "repository.productImage.SetPrimary")
+	defer span.End()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, productID); err != nil {
+			return err
+		}
+
+		if err := clearPrimaryImage(tx, productID); err != nil {
+			return err
+		}
+
+		result := tx.Model(&entity.ProductImage{}).
+			Where("id = ? AND product_id = ?", id, productID).
+			Updates(map[string]interface{}{
+				"is_primary": true,
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to set primary product image: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return entity.ErrProductImageNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// lockProduct serializes image changes per product so positions and the primary flag stay consistent
+func lockProduct(tx *gorm.DB, productID uuid.UUID) error {
+	var product entity.Product
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&product, "id = ?", productID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("product not found")
+		}
+		return fmt.Errorf("failed to lock product: %w", err)
+	}
+	return nil
+}
+
+func clearPrimaryImage(tx *gorm.DB, productID uuid.UUID) error {
+	if err := tx.Model(&entity.ProductImage{}).
+		Where("product_id = ? AND is_primary", productID).
+		Updates(map[string]interface{}{
+			"is_primary": false,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to clear primary product image: %w", err)
+	}
+	return nil
+}
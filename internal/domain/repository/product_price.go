@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/actor"
+	"Unnispick/pkg/money"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type productPriceRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewProductPriceRepository(db *gorm.DB, tracer *tracing.Tracer) entity.ProductPriceRepository {
+	return &productPriceRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+// recordPriceChange closes the current price period and opens a new one at the given time,
+// it must be called with the transaction that changed the product price
+func recordPriceChange(ctx context.Context, tx *gorm.DB, productID uuid.UUID, price money.Money, at time.Time) error {
+	if err := closeCurrentPrice(tx, productID, at); err != nil {
+		return err
+	}
+
+	entry := &entity.ProductPrice{
+		ProductID:     productID,
+		Price:         price,
+		EffectiveFrom: at,
+		AppliedAt:     &at,
+		Actor:         actor.FromContext(ctx),
+	}
+	if err := tx.Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to record price change: %w", err)
+	}
+
+	return nil
+}
+
+func closeCurrentPrice(tx *gorm.DB, productID uuid.UUID, at time.Time) error {
+	if err := tx.Model(&entity.ProductPrice{}).
+		Where("product_id = ? AND applied_at IS NOT NULL AND effective_to IS NULL", productID).
+		Update("effective_to", at).Error; err != nil {
+		return fmt.Errorf("failed to close current price: %w", err)
+	}
+
+	return nil
+}
+
+func (r *productPriceRepository) GetHistoryWithFilter(ctx context.Context, productID uuid.UUID, filter entity.PriceHistoryFilterRepository) (prices []entity.ProductPrice, count int64, err error) {
+	ctx, span := r.tracer.Start(ctx, "repository.productPrice.GetHistoryWithFilter")
+	defer span.End()
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("invalid pagination parameters: limit and offset must be non-negative")
+	}
+
+	query := r.db.WithContext(ctx).
+		Model(&entity.ProductPrice{}).
+		Where("product_id = ? AND applied_at IS NOT NULL", productID)
+
+	// Count total records
+	if err = query.Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to count price history: %w", err)
+	}
+
+	// Check if offset is beyond total count
+	if count > 0 && filter.Offset >= int(count) {
+		return []entity.ProductPrice{}, count, nil
+	}
+
+	// Get paginated records
+	if err = query.
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Order("effective_from DESC").
+		Find(&prices).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to list price history: %w", err)
+	}
+
+	return prices, count, nil
+}
+
+func (r *productPriceRepository) GetScheduled(ctx context.Context, productID uuid.UUID) ([]entity.ProductPrice, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.productPrice.GetScheduled")
+	defer span.End()
+
+	var prices []entity.ProductPrice
+	if err := r.db.WithContext(ctx).
+		Where("product_id = ? AND applied_at IS NULL", productID).
+		Order("effective_from").
+		Find(&prices).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to list scheduled prices: %w", err)
+	}
+
+	return prices, nil
+}
+
+func (r *productPriceRepository) Schedule(ctx context.Context, price *entity.ProductPrice) error {
+	ctx, span := r.tracer.Start(ctx, "repository.productPrice.Schedule")
+	defer span.End()
+
+	price.Actor = actor.FromContext(ctx)
+	if err := r.db.WithContext(ctx).Create(price).Error; err != nil {
+		tracer.RecordError(span, err)
+		return fmt.Errorf("failed to schedule price change: %w", err)
+	}
+
+	return nil
+}
+
+func (r *productPriceRepository) CancelScheduled(ctx context.Context, productID, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "repository.productPrice.CancelScheduled")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND product_id = ? AND applied_at IS NULL", id, productID).
+		Delete(&entity.ProductPrice{})
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to cancel scheduled price change: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrPriceScheduleNotFound
+	}
+
+	return nil
+}
+
+func (r *productPriceRepository) ApplyDue(ctx context.Context, now time.Time) ([]entity.AppliedPriceChange, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.productPrice.ApplyDue")
+	defer span.End()
+
+	var (
+		applied []entity.AppliedPriceChange
+		failed  []error
+	)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Skip rows another API instance is already applying. Changes of trashed products wait
+		// for a restore, a purge drops them with the product
+		var due []entity.ProductPrice
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("applied_at IS NULL AND effective_from <= ?", now).
+			Where("EXISTS (SELECT 1 FROM products p WHERE p.id = product_prices.product_id AND p.deleted_at IS NULL)").
+			Order("effective_from").
+			Find(&due).Error; err != nil {
+			return fmt.Errorf("failed to get due price changes: %w", err)
+		}
+
+		// Each change is applied in its own savepoint, one that fails is left due and does not
+		// hold back the others
+		for i := range due {
+			price := &due[i]
+			var previous money.Money
+			err := tx.Transaction(func(tx *gorm.DB) (err error) {
+				previous, err = applyScheduledPrice(tx, price, now)
+				return err
+			})
+			switch {
+			case errors.Is(err, errScheduledProductGone):
+			case err != nil:
+				failed = append(failed, fmt.Errorf("price change %s: %w", price.ID, err))
+			default:
+				applied = append(applied, entity.AppliedPriceChange{ProductPrice: *price, Previous: previous})
+			}
+		}
+
+		return nil
+	})
+	if err == nil {
+		err = errors.Join(failed...)
+	}
+	if err != nil {
+		tracer.RecordError(span, err)
+		return applied, err
+	}
+
+	return applied, nil
+}
+
+// errScheduledProductGone reports a scheduled change whose product was trashed after it was found
+// due, the change is left due until the product is restored
+var errScheduledProductGone = errors.New("product of the scheduled price change is gone")
+
+// applyScheduledPrice moves the product to the scheduled price and returns the price it replaced
+func applyScheduledPrice(tx *gorm.DB, price *entity.ProductPrice, now time.Time) (money.Money, error) {
+	var product entity.Product
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "price_amount", "price_currency").
+		Where("id = ?", price.ProductID).
+		Limit(1).
+		Find(&product)
+	if result.Error != nil {
+		return money.Money{}, fmt.Errorf("failed to get product price: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return money.Money{}, errScheduledProductGone
+	}
+
+	if err := tx.Model(&entity.Product{}).
+		Where("id = ?", price.ProductID).
+		Updates(map[string]interface{}{
+			"price_amount":   price.Price.Amount,
+			"price_currency": price.Price.Currency,
+			"version":        gorm.Expr("version + 1"),
+			"updated_at":     now,
+		}).Error; err != nil {
+		return money.Money{}, fmt.Errorf("failed to update product price: %w", err)
+	}
+
+	// A manual change made after the scheduled time opened the current period later, the
+	// schedule then takes effect from that time so the periods stay in order
+	effectiveFrom := price.EffectiveFrom
+	var opened sql.NullTime
+	if err := tx.Model(&entity.ProductPrice{}).
+		Select("MAX(effective_from)").
+		Where("product_id = ? AND applied_at IS NOT NULL AND effective_to IS NULL", price.ProductID).
+		Scan(&opened).Error; err != nil {
+		return money.Money{}, fmt.Errorf("failed to get current price: %w", err)
+	}
+	if opened.Valid && opened.Time.After(effectiveFrom) {
+		effectiveFrom = opened.Time
+	}
+
+	if err := closeCurrentPrice(tx, price.ProductID, effectiveFrom); err != nil {
+		return money.Money{}, err
+	}
+	if err := tx.Model(price).Updates(map[string]interface{}{
+		"effective_from": effectiveFrom,
+		"applied_at":     now,
+	}).Error; err != nil {
+		return money.Money{}, fmt.Errorf("failed to mark price change applied: %w", err)
+	}
+
+	return product.Price, nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type productVariantRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewProductVariantRepository(db *gorm.DB, tracer *tracing.Tracer) entity.ProductVariantRepository {
+	return &productVariantRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+// orderVariants keeps variants in the order they were added when preloaded with a product
+func orderVariants(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at ASC")
+}
+
+func (r *productVariantRepository) Create(ctx context.Context, variant *entity.ProductVariant) error {
+	ctx, span := r.tracer.Start(ctx, "repository.productVariant.Create")
+	defer span.End()
+
+	if err := r.db.WithContext(ctx).Create(variant).Error; err != nil {
+		tracer.RecordError(span, err)
+		return fmt.Errorf("failed to create product variant: %w", err)
+	}
+
+	return nil
+}
+
+func (r *productVariantRepository) GetByID(ctx context.Context, productID, id uuid.UUID) (*entity.ProductVariant, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.productVariant.GetByID")
+	defer span.End()
+
+	var variant entity.ProductVariant
+	if err := r.db.WithContext(ctx).
+		First(&variant, "id = ? AND product_id = ?", id, productID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get product variant: %w", err)
+	}
+
+	return &variant, nil
+}
+
+func (r *productVariantRepository) GetAllByProductID(ctx context.Context, productID uuid.UUID) ([]entity.ProductVariant, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.productVariant.GetAllByProductID")
+	defer span.End()
+
+	var variants []entity.ProductVariant
+	if err := orderVariants(r.db.WithContext(ctx)).
+		Where("product_id = ?", productID).
+		Find(&variants).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to list product variants: %w", err)
+	}
+
+	return variants, nil
+}
+
+func (r *productVariantRepository) Update(ctx context.Context, variant *entity.ProductVariant) error {
+	ctx, span := r.tracer.Start(ctx, "repository.productVariant.Update")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Model(variant).Updates(map[string]interface{}{
+		"sku":            variant.SKU,
+		"variant_name":   variant.VariantName,
+		"shade":          variant.Shade,
+		"size":           variant.Size,
+		"volume":         variant.Volume,
+		"price_amount":   variant.Price.Amount,
+		"price_currency": variant.Price.Currency,
+		"quantity":       variant.Quantity,
+		"updated_at":     time.Now(),
+	})
+
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to update product variant: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.ErrProductVariantNotFound
+	}
+
+	return nil
+}
+
+func (r *productVariantRepository) Delete(ctx context.Context, productID, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "repository.productVariant.Delete")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Delete(&entity.ProductVariant{}, "id = ? AND product_id = ?", id, productID)
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to delete product variant: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.ErrProductVariantNotFound
+	}
+
+	return nil
+}
+
+func (r *productVariantRepository) ExistsBySKU(ctx context.Context, sku string) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.productVariant.ExistsBySKU")
+	defer span.End()
+
+	var exists bool
+	err := r.db.WithContext(ctx).
+		Model(&entity.ProductVariant{}).
+		Select("1").
+		Where("sku = ?", sku).
+		Scan(&exists).Error
+
+	if err != nil {
+		tracer.RecordError(span, err)
+		return false, fmt.Errorf("failed to check product variant sku existence: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type promotionRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewPromotionRepository(db *gorm.DB, tracer *tracing.Tracer) entity.PromotionRepository {
+	return &promotionRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+// runningAt keeps promotions whose period contains the given time
+func runningAt(at time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", at, at)
+	}
+}
+
+func (r *promotionRepository) Create(ctx context.Context, promotion *entity.Promotion) error {
+	ctx, span := r.tracer.Start(ctx, "repository.promotion.Create")
+	defer span.End()
+
+	if err := r.db.WithContext(ctx).Create(promotion).Error; err != nil {
+		tracer.RecordError(span, err)
+		return fmt.Errorf("failed to create promotion: %w", err)
+	}
+
+	return nil
+}
+
+func (r *promotionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.promotion.GetByID")
+	defer span.End()
+
+	var promotion entity.Promotion
+	if err := r.db.WithContext(ctx).First(&promotion, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+
+	return &promotion, nil
+}
+
+func (r *promotionRepository) GetAllWithFilter(ctx context.Context, filter entity.PromotionFilterRepository) (promotions []entity.Promotion, count int64, err error) {
+	ctx, span := r.tracer.Start(ctx, "repository.promotion.GetAllWithFilter")
+	defer span.End()
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("invalid pagination parameters: limit and offset must be non-negative")
+	}
+
+	query := r.db.WithContext(ctx).Model(&entity.Promotion{})
+
+	// Apply filters
+	if filter.ScopeType != "" {
+		query = query.Where("scope_type = ?", filter.ScopeType)
+	}
+	if filter.ScopeID != uuid.Nil {
+		query = query.Where("scope_id = ?", filter.ScopeID)
+	}
+	if filter.ActiveAt != nil {
+		query = query.Scopes(runningAt(*filter.ActiveAt))
+	}
+
+	// Count total records
+	if err = query.Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to count promotions: %w", err)
+	}
+
+	// Check if offset is beyond total count
+	if count > 0 && filter.Offset >= int(count) {
+		return []entity.Promotion{}, count, nil
+	}
+
+	// Get paginated records
+	if err = query.
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Order("starts_at DESC").
+		Find(&promotions).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to list promotions: %w", err)
+	}
+
+	return promotions, count, nil
+}
+
+func (r *promotionRepository) Update(ctx context.Context, promotion *entity.Promotion) error {
+	ctx, span := r.tracer.Start(ctx, "repository.promotion.Update")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Model(promotion).Updates(map[string]interface{}{
+		"promotion_name":      promotion.PromotionName,
+		"discount_type":       promotion.DiscountType,
+		"percentage":          promotion.Percentage,
+		"amount_off_amount":   promotion.AmountOff.Amount,
+		"amount_off_currency": promotion.AmountOff.Currency,
+		"scope_type":          promotion.ScopeType,
+		"scope_id":            promotion.ScopeID,
+		"starts_at":           promotion.StartsAt,
+		"ends_at":             promotion.EndsAt,
+		"priority":            promotion.Priority,
+		"stackable":           promotion.Stackable,
+		"updated_at":          time.Now(),
+	})
+
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to update promotion: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.ErrPromotionNotFound
+	}
+
+	return nil
+}
+
+func (r *promotionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "repository.promotion.Delete")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Delete(&entity.Promotion{}, "id = ?", id)
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to delete promotion: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.ErrPromotionNotFound
+	}
+
+	return nil
+}
+
+func (r *promotionRepository) GetActive(ctx context.Context, at time.Time) ([]entity.Promotion, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.promotion.GetActive")
+	defer span.End()
+
+	// Evaluation order: highest priority first, older promotions first on a tie
+	var promotions []entity.Promotion
+	if err := r.db.WithContext(ctx).
+		Scopes(runningAt(at)).
+		Order("priority DESC, created_at ASC").
+		Find(&promotions).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get active promotions: %w", err)
+	}
+
+	return promotions, nil
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// reservedQuantitySQL sums the units held by active reservations of the product in the outer query
+const reservedQuantitySQL = `(SELECT COALESCE(SUM(r.quantity), 0) FROM stock_reservations r
+	WHERE r.product_id = products.id AND r.status = 'pending' AND r.expires_at > NOW())`
+
+type reservationRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewReservationRepository(db *gorm.DB, tracer *tracing.Tracer) entity.ReservationRepository {
+	return &reservationRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+func (r *reservationRepository) Create(ctx context.Context, reservation *entity.Reservation) error {
+	ctx, span := r.tracer.Start(ctx, "repository.reservation.Create")
+	defer span.End()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the product so concurrent holds are checked against the same availability
+		var product entity.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "quantity", "product_type").
+			First(&product, "id = ?", reservation.ProductID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("product not found")
+			}
+			return fmt.Errorf("failed to get product: %w", err)
+		}
+		if product.IsBundle() {
+			return entity.ErrBundleStockDerived
+		}
+
+		var reserved int
+		if err := tx.Model(&entity.Reservation{}).
+			Select("COALESCE(SUM(quantity), 0)").
+			Where("product_id = ? AND status = ? AND expires_at > NOW()", reservation.ProductID, entity.ReservationStatusPending).
+			Scan(&reserved).Error; err != nil {
+			return fmt.Errorf("failed to sum reserved quantity: %w", err)
+		}
+
+		if product.Quantity-reserved < reservation.Quantity {
+			return entity.ErrInsufficientStock
+		}
+
+		if err := tx.Create(reservation).Error; err != nil {
+			return fmt.Errorf("failed to create reservation: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *reservationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Reservation, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.reservation.GetByID")
+	defer span.End()
+
+	var reservation entity.Reservation
+	if err := r.db.WithContext(ctx).First(&reservation, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	return &reservation, nil
+}
+
+func (r *reservationRepository) Confirm(ctx context.Context, id uuid.UUID) (*entity.Reservation, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.reservation.Confirm")
+	defer span.End()
+
+	var reservation entity.Reservation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockPendingReservation(tx, id, &reservation); err != nil {
+			return err
+		}
+		if !reservation.ExpiresAt.After(time.Now()) {
+			return entity.ErrReservationExpired
+		}
+
+		// The held units become a permanent decrement of the product stock
+		result := tx.Model(&entity.Product{}).
+			Where("id = ? AND quantity >= ?", reservation.ProductID, reservation.Quantity).
+			Updates(map[string]interface{}{
+				"quantity":   gorm.Expr("quantity - ?", reservation.Quantity),
+				"version":    gorm.Expr("version + 1"),
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to decrease product stock: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return entity.ErrInsufficientStock
+		}
+
+		if err := recordStockMovement(ctx, tx, reservation.ProductID, -reservation.Quantity, entity.StockMovementNote{
+			Reason:    entity.StockReasonReservationConfirmed,
+			Reference: reservation.ID.String(),
+		}); err != nil {
+			return err
+		}
+
+		return updateReservationStatus(tx, &reservation, entity.ReservationStatusConfirmed)
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+func (r *reservationRepository) Release(ctx context.Context, id uuid.UUID) (*entity.Reservation, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.reservation.Release")
+	defer span.End()
+
+	var reservation entity.Reservation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockPendingReservation(tx, id, &reservation); err != nil {
+			return err
+		}
+
+		return updateReservationStatus(tx, &reservation, entity.ReservationStatusReleased)
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+func (r *reservationRepository) ExpireStale(ctx context.Context, now time.Time) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.reservation.ExpireStale")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).
+		Model(&entity.Reservation{}).
+		Where("status = ? AND expires_at <= ?", entity.ReservationStatusPending, now).
+		Updates(map[string]interface{}{
+			"status":     entity.ReservationStatusExpired,
+			"updated_at": now,
+		})
+
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return 0, fmt.Errorf("failed to expire reservations: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func lockPendingReservation(tx *gorm.DB, id uuid.UUID, reservation *entity.Reservation) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(reservation, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.ErrReservationNotFound
+		}
+		return fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	if reservation.Status != entity.ReservationStatusPending {
+		return entity.ErrReservationNotPending
+	}
+
+	return nil
+}
+
+func updateReservationStatus(tx *gorm.DB, reservation *entity.Reservation, status string) error {
+	now := time.Now()
+	if err := tx.Model(reservation).Updates(map[string]interface{}{
+		"status":     status,
+		"updated_at": now,
+	}).Error; err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	reservation.Status = status
+	reservation.UpdatedAt = now
+	return nil
+}
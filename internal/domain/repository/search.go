@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"strings"
+	"unicode"
+)
+
+// tsQuerySQL parses a query built by searchQuery with the configuration of the search vectors.
+// Brand and product names are proper nouns in several languages, so words are only lowercased and
+// never stemmed.
+const tsQuerySQL = "to_tsquery('simple', ?)"
+
+const (
+	// headlineMarkers are the ts_headline options that mark matches, the text is escaped to HTML
+	// once the markers are in place
+	headlineMarkers = `StartSel="` + entity.SearchMatchStart + `", StopSel="` + entity.SearchMatchStop + `"`
+	// headlineNameOptions mark every match of a short field and keep the whole text
+	headlineNameOptions = headlineMarkers + ", HighlightAll=true"
+	// headlineTextOptions cut a long field down to the fragments around its matches
+	headlineTextOptions = headlineMarkers + ", MaxFragments=2, MaxWords=20, MinWords=8"
+)
+
+// searchQuery turns free text into a tsquery matching every word of it, the last word as a prefix
+// so the text matches while it is still being typed. Only letters and digits are kept, the result
+// is empty when the text has no words.
+func searchQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = "'" + word + "'"
+	}
+	terms[len(terms)-1] += ":*"
+	return strings.Join(terms, " & ")
+}
+
+// productHighlightSQL selects the product name and the name and description of its brand with the
+// words of a search marked, it takes the query and headline options of each field in turn
+var productHighlightSQL = headlineSQL("products.product_name") + ` AS highlighted_name,
+	(SELECT ` + headlineSQL("b.brand_name") + ` FROM brands b WHERE b.id = products.brand_id) AS highlighted_brand_name,
+	(SELECT ` + headlineSQL("b.description") + ` FROM brands b WHERE b.id = products.brand_id) AS highlighted_description`
+
+// headlineSQL marks the words of a search in the text of column, it takes the query and the
+// headline options. Marker characters the text itself carries are dropped so only matches are
+// marked.
+func headlineSQL(column string) string {
+	return "ts_headline('simple', translate(" + column + ", '" + entity.SearchMatchStart + entity.SearchMatchStop + "', ''), " +
+		tsQuerySQL + ", ?)"
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Unique indexes on the current slug, a slug taken by a concurrent write past the IsSlugTaken
+// check violates them
+const (
+	brandSlugIndex   = "idx_brands_slug"
+	productSlugIndex = "idx_products_slug"
+)
+
+// uniqueViolationCode is the SQLSTATE Postgres reports a unique index violation with
+const uniqueViolationCode = "23505"
+
+type slugRedirectRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewSlugRedirectRepository(db *gorm.DB, tracer *tracing.Tracer) entity.SlugRedirectRepository {
+	return &slugRedirectRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+func (r *slugRedirectRepository) GetBySlug(ctx context.Context, entityType, slug string) (*entity.SlugRedirect, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.slug_redirect.GetBySlug")
+	defer span.End()
+
+	var redirect entity.SlugRedirect
+	if err := r.db.WithContext(ctx).
+		First(&redirect, "entity_type = ? AND slug = ?", entityType, slug).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get slug redirect: %w", err)
+	}
+
+	return &redirect, nil
+}
+
+// isSlugTaken reports whether slug is the current slug of another row in table, or an old slug
+// that still redirects to another entity of the same type
+func isSlugTaken(ctx context.Context, db *gorm.DB, table, entityType, slug string, exceptID uuid.UUID) (bool, error) {
+	var taken bool
+	err := db.WithContext(ctx).Raw(
+		"SELECT EXISTS (SELECT 1 FROM "+table+" WHERE slug = ? AND id <> ?) "+
+			"OR EXISTS (SELECT 1 FROM slug_redirects WHERE entity_type = ? AND slug = ? AND entity_id <> ?)",
+		slug, exceptID, entityType, slug, exceptID,
+	).Scan(&taken).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check slug availability: %w", err)
+	}
+	return taken, nil
+}
+
+// isUniqueViolation reports whether err is a violation of the named unique index
+func isUniqueViolation(err error, index string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == index
+}
+
+// moveSlug keeps from as a redirect to the entity, and drops any redirect for to since the
+// entity now owns it again
+func moveSlug(ctx context.Context, tx *gorm.DB, entityType string, id uuid.UUID, from, to string) error {
+	if err := tx.WithContext(ctx).
+		Where("entity_type = ? AND slug = ?", entityType, to).
+		Delete(&entity.SlugRedirect{}).Error; err != nil {
+		return fmt.Errorf("failed to release slug redirect: %w", err)
+	}
+
+	redirect := entity.SlugRedirect{EntityType: entityType, Slug: from, EntityID: id}
+	if err := tx.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "entity_type"}, {Name: "slug"}},
+			DoUpdates: clause.AssignmentColumns([]string{"entity_id", "created_at"}),
+		}).
+		Create(&redirect).Error; err != nil {
+		return fmt.Errorf("failed to record slug redirect: %w", err)
+	}
+
+	return nil
+}
+
+func deleteSlugRedirects(ctx context.Context, tx *gorm.DB, entityType string, ids []uuid.UUID) error {
+	if err := tx.WithContext(ctx).
+		Where("entity_type = ? AND entity_id IN ?", entityType, ids).
+		Delete(&entity.SlugRedirect{}).Error; err != nil {
+		return fmt.Errorf("failed to delete slug redirects: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/actor"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type stockMovementRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewStockMovementRepository(db *gorm.DB, tracer *tracing.Tracer) entity.StockMovementRepository {
+	return &stockMovementRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+// recordStockMovement appends a ledger entry, it must be called with the transaction that changed the quantity
+func recordStockMovement(ctx context.Context, tx *gorm.DB, productID uuid.UUID, delta int, note entity.StockMovementNote) error {
+	if delta == 0 {
+		return nil
+	}
+
+	movement := &entity.StockMovement{
+		ProductID: productID,
+		Delta:     delta,
+		Reason:    note.Reason,
+		Reference: note.Reference,
+		Actor:     actor.FromContext(ctx),
+	}
+	if err := tx.Create(movement).Error; err != nil {
+		return fmt.Errorf("failed to record stock movement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *stockMovementRepository) GetAllByProductWithFilter(ctx context.Context, productID uuid.UUID, filter entity.StockMovementFilterRepository) (movements []entity.StockMovement, count int64, err error) {
+	ctx, span := r.tracer.Start(ctx, "repository.stockMovement.GetAllByProductWithFilter")
+	defer span.End()
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("invalid pagination parameters: limit and offset must be non-negative")
+	}
+
+	query := r.db.WithContext(ctx).
+		Model(&entity.StockMovement{}).
+		Where("product_id = ?", productID)
+
+	// Count total records
+	if err = query.Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to count stock movements: %w", err)
+	}
+
+	// Check if offset is beyond total count
+	if count > 0 && filter.Offset >= int(count) {
+		return []entity.StockMovement{}, count, nil
+	}
+
+	// Get paginated records
+	if err = query.
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Order("created_at DESC").
+		Find(&movements).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+
+	return movements, count, nil
+}
+
+func (r *stockMovementRepository) Reconcile(ctx context.Context) ([]entity.StockDiscrepancy, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.stockMovement.Reconcile")
+	defer span.End()
+
+	var discrepancies []entity.StockDiscrepancy
+	err := r.db.WithContext(ctx).
+		Table("products AS p").
+		Select("p.id AS product_id, p.product_name, p.quantity, COALESCE(SUM(m.delta), 0) AS ledger_quantity").
+		Joins("LEFT JOIN stock_movements AS m ON m.product_id = p.id").
+		Group("p.id, p.product_name, p.quantity").
+		Having("p.quantity <> COALESCE(SUM(m.delta), 0)").
+		Order("p.product_name").
+		Scan(&discrepancies).Error
+
+	if err != nil {
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to reconcile stock movements: %w", err)
+	}
+
+	return discrepancies, nil
+}
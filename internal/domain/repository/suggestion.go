@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"strconv"
+)
+
+// suggestionSQL matches brand and product names by trigram word similarity, which scores the text
+// against the closest run of words in a name so a long product name is not penalised for the
+// words that were not typed. It takes the text twice per table, the product status and the limit.
+const suggestionSQL = `SELECT 'brand' AS type, id, brand_name AS name, slug, word_similarity(?, brand_name) AS similarity
+FROM brands
+WHERE deleted_at IS NULL AND ? <% brand_name
+UNION ALL
+SELECT 'product' AS type, id, product_name AS name, slug, word_similarity(?, product_name) AS similarity
+FROM products
+WHERE deleted_at IS NULL AND status = ? AND ? <% product_name
+ORDER BY similarity DESC, name
+LIMIT ?`
+
+type suggestionRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewSuggestionRepository(db *gorm.DB, tracer *tracing.Tracer) entity.SuggestionRepository {
+	return &suggestionRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+func (r *suggestionRepository) Suggest(ctx context.Context, text string, threshold float64, limit int) ([]entity.Suggestion, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.suggestion.Suggest")
+	defer span.End()
+
+	var suggestions []entity.Suggestion
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The <% operator reads its threshold from the session, setting it for this transaction
+		// only keeps the match on the trigram indexes
+		if err := tx.Exec("SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)",
+			strconv.FormatFloat(threshold, 'f', -1, 64)).Error; err != nil {
+			return fmt.Errorf("failed to set similarity threshold: %w", err)
+		}
+
+		if err := tx.Raw(suggestionSQL, text, text, text, entity.ProductStatusActive, text, limit).
+			Scan(&suggestions).Error; err != nil {
+			return fmt.Errorf("failed to get suggestions: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	return suggestions, nil
+}
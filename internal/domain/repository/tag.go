@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/telemetry/tracer"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type tagRepository struct {
+	db     *gorm.DB
+	tracer *tracing.Tracer
+}
+
+func NewTagRepository(db *gorm.DB, tracer *tracing.Tracer) entity.TagRepository {
+	return &tagRepository{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+// orderTags keeps tags alphabetical when preloaded with a product
+func orderTags(db *gorm.DB) *gorm.DB {
+	return db.Order("tag_name ASC")
+}
+
+func (r *tagRepository) Create(ctx context.Context, tag *entity.Tag) error {
+	ctx, span := r.tracer.Start(ctx, "repository.tag.Create")
+	defer span.End()
+
+	if err := r.db.WithContext(ctx).Create(tag).Error; err != nil {
+		tracer.RecordError(span, err)
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	return nil
+}
+
+func (r *tagRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Tag, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.tag.GetByID")
+	defer span.End()
+
+	var tag entity.Tag
+	if err := r.db.WithContext(ctx).First(&tag, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		tracer.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	return &tag, nil
+}
+
+func (r *tagRepository) GetAllWithFilter(ctx context.Context, filter entity.TagFilterRepository) (tags []entity.Tag, count int64, err error) {
+	ctx, span := r.tracer.Start(ctx, "repository.tag.GetAllWithFilter")
+	defer span.End()
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("invalid pagination parameters: limit and offset must be non-negative")
+	}
+
+	query := r.db.WithContext(ctx).Model(&entity.Tag{})
+
+	// Apply search filter
+	if filter.Search != "" {
+		query = query.Where("tag_name ILIKE ?", "%"+filter.Search+"%")
+	}
+
+	// Count total records
+	if err = query.Count(&count).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to count tags: %w", err)
+	}
+
+	// Check if offset is beyond total count
+	if count > 0 && filter.Offset >= int(count) {
+		return []entity.Tag{}, count, nil
+	}
+
+	// Get paginated records
+	if err = query.
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Order("tag_name ASC").
+		Find(&tags).Error; err != nil {
+		tracer.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	return tags, count, nil
+}
+
+func (r *tagRepository) Update(ctx context.Context, tag *entity.Tag) error {
+	ctx, span := r.tracer.Start(ctx, "repository.tag.Update")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Model(tag).Updates(map[string]interface{}{
+		"tag_name":   tag.TagName,
+		"updated_at": time.Now(),
+	})
+
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to update tag: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("tag not found")
+	}
+
+	return nil
+}
+
+func (r *tagRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "repository.tag.Delete")
+	defer span.End()
+
+	// Product assignments are removed by the ON DELETE CASCADE of product_tags
+	result := r.db.WithContext(ctx).Delete(&entity.Tag{}, "id = ?", id)
+	if result.Error != nil {
+		tracer.RecordError(span, result.Error)
+		return fmt.Errorf("failed to delete tag: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("tag not found")
+	}
+
+	return nil
+}
+
+func (r *tagRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.tag.ExistsByName")
+	defer span.End()
+
+	var exists bool
+	err := r.db.WithContext(ctx).
+		Model(&entity.Tag{}).
+		Select("1").
+		Where("tag_name = ?", name).
+		Scan(&exists).Error
+
+	if err != nil {
+		tracer.RecordError(span, err)
+		return false, fmt.Errorf("failed to check tag name existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *tagRepository) FindOrCreateByNames(ctx context.Context, names []string) ([]entity.Tag, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.tag.FindOrCreateByNames")
+	defer span.End()
+
+	if len(names) == 0 {
+		return []entity.Tag{}, nil
+	}
+
+	var tags []entity.Tag
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		missing := make([]entity.Tag, len(names))
+		for i, name := range names {
+			missing[i] = entity.Tag{TagName: name}
+		}
+		// Concurrent requests may create the same tag, the unique index keeps a single row
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tag_name"}},
+			DoNothing: true,
+		}).Create(&missing).Error; err != nil {
+			return fmt.Errorf("failed to create tags: %w", err)
+		}
+
+		if err := tx.Where("tag_name IN ?", names).Find(&tags).Error; err != nil {
+			return fmt.Errorf("failed to get tags: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/actor"
+	"context"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type auditService struct {
+	repo   entity.AuditRepository
+	logger *zap.Logger
+	tracer *tracing.Tracer
+}
+
+func NewAuditService(repo entity.AuditRepository, logger *zap.Logger, tracer *tracing.Tracer) entity.AuditService {
+	return &auditService{
+		repo:   repo,
+		logger: logger,
+		tracer: tracer,
+	}
+}
+
+func (s *auditService) Record(ctx context.Context, action, entityType string, entityID uuid.UUID, before, after entity.AuditSnapshot) {
+	ctx, span := s.tracer.Start(ctx, "service.audit.Record")
+	defer span.End()
+
+	changes, err := entity.DiffAuditSnapshots(before, after)
+	if err != nil {
+		s.logger.Error("failed to diff audit snapshots", zap.Error(err))
+		return
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	log := &entity.AuditLog{
+		Actor:      actor.FromContext(ctx),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+	}
+	// The trace of the request that made the change, when tracing is on
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		log.TraceID = spanContext.TraceID().String()
+	}
+
+	if err := s.repo.Create(ctx, log); err != nil {
+		s.logger.Error("failed to record audit log",
+			zap.Error(err),
+			zap.String("action", action),
+			zap.String("entity_type", entityType),
+			zap.String("entity_id", entityID.String()),
+		)
+	}
+}
+
+func (s *auditService) GetAll(ctx context.Context, filter entity.AuditFilterRequest) ([]entity.AuditLogResponse, int64, error) {
+	ctx, span := s.tracer.Start(ctx, "service.audit.GetAll")
+	defer span.End()
+
+	logs, count, err := s.repo.GetAllWithFilter(ctx, filter.ToAuditFilterRepo())
+	if err != nil {
+		s.logger.Error("failed to get audit logs", zap.Error(err))
+		return nil, 0, err
+	}
+
+	responses := make([]entity.AuditLogResponse, len(logs))
+	for i, log := range logs {
+		responses[i] = *log.ToResponseDTO()
+	}
+
+	return responses, count, nil
+}
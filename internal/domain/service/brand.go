@@ -4,23 +4,33 @@ import (
 	"Unnispick/internal/domain/entity"
 	"Unnispick/internal/infra/tracing"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
-	"time"
 )
 
 type brandService struct {
-	repo   entity.BrandRepository
-	logger *zap.Logger
-	tracer *tracing.Tracer
+	repo         entity.BrandRepository
+	redirectRepo entity.SlugRedirectRepository
+	audit        entity.AuditService
+	logger       *zap.Logger
+	tracer       *tracing.Tracer
 }
 
-func NewBrandService(repo entity.BrandRepository, logger *zap.Logger, tracer *tracing.Tracer) entity.BrandService {
+func NewBrandService(
+	repo entity.BrandRepository,
+	redirectRepo entity.SlugRedirectRepository,
+	audit entity.AuditService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) entity.BrandService {
 	return &brandService{
-		repo:   repo,
-		logger: logger,
-		tracer: tracer,
+		repo:         repo,
+		redirectRepo: redirectRepo,
+		audit:        audit,
+		logger:       logger,
+		tracer:       tracer,
 	}
 }
 
@@ -43,10 +53,15 @@ func (s *brandService) Create(ctx context.Context, req entity.CreateBrandRequest
 	}
 
 	brand := req.ToBrandEntity()
+	if err := s.assignSlug(ctx, brand, req.Slug); err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.Create(ctx, brand); err != nil {
 		s.logger.Error("failed to create brand", zap.Error(err))
 		return nil, err
 	}
+	s.audit.Record(ctx, entity.AuditActionCreate, entity.AuditEntityBrand, brand.ID, nil, brand.AuditSnapshot())
 
 	return s.toResponse(brand), nil
 }
@@ -85,7 +100,39 @@ func (s *brandService) GetAll(ctx context.Context, filter entity.BrandFilterRequ
 	return responses, count, nil
 }
 
-func (s *brandService) Update(ctx context.Context, id uuid.UUID, req entity.UpdateBrandRequest) (*entity.BrandResponse, error) {
+func (s *brandService) GetBySlug(ctx context.Context, slug string) (*entity.BrandResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.brand.GetBySlug")
+	defer span.End()
+
+	brand, err := s.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		s.logger.Error("failed to get brand by slug", zap.Error(err))
+		return nil, err
+	}
+	if brand != nil {
+		return s.toResponse(brand), nil
+	}
+
+	redirect, err := s.redirectRepo.GetBySlug(ctx, entity.SlugEntityBrand, slug)
+	if err != nil {
+		s.logger.Error("failed to get brand slug redirect", zap.Error(err))
+		return nil, err
+	}
+	if redirect != nil {
+		brand, err = s.repo.GetByID(ctx, redirect.EntityID)
+		if err != nil {
+			s.logger.Error("failed to get brand", zap.Error(err))
+			return nil, err
+		}
+		if brand != nil {
+			return nil, &entity.SlugMovedError{Slug: brand.Slug}
+		}
+	}
+
+	return nil, fmt.Errorf("brand not found")
+}
+
+func (s *brandService) Update(ctx context.Context, id uuid.UUID, version int, req entity.UpdateBrandRequest) (*entity.BrandResponse, error) {
 	ctx, span := s.tracer.Start(ctx, "service.brand.Update")
 	defer span.End()
 
@@ -102,6 +149,9 @@ func (s *brandService) Update(ctx context.Context, id uuid.UUID, req entity.Upda
 	if brand == nil {
 		return nil, fmt.Errorf("brand not found")
 	}
+	if brand.Version != version {
+		return nil, fmt.Errorf("%w: brand is at version %d", entity.ErrVersionMismatch, brand.Version)
+	}
 
 	if brand.BrandName != req.BrandName {
 		exists, err := s.repo.ExistsByName(ctx, req.BrandName)
@@ -114,41 +164,116 @@ func (s *brandService) Update(ctx context.Context, id uuid.UUID, req entity.Upda
 		}
 	}
 
+	before := brand.AuditSnapshot()
+	renamed := brand.BrandName != req.BrandName
 	brand.UpdateFromRequest(req)
+
+	// A rename moves the brand to a slug of its new name unless one is given
+	if req.Slug != "" || renamed {
+		if err := s.assignSlug(ctx, brand, req.Slug); err != nil {
+			return nil, err
+		}
+	}
 	if err := s.repo.Update(ctx, brand); err != nil {
 		s.logger.Error("failed to update brand", zap.Error(err))
 		return nil, err
 	}
+	s.audit.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityBrand, brand.ID, before, brand.AuditSnapshot())
 
 	return s.toResponse(brand), nil
 }
 
-func (s *brandService) Delete(ctx context.Context, id uuid.UUID) error {
+func (s *brandService) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	ctx, span := s.tracer.Start(ctx, "service.brand.Delete")
 	defer span.End()
 
-	exists, err := s.repo.ExistsByID(ctx, id)
+	brand, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		s.logger.Error("failed to check brand existence", zap.Error(err))
+		s.logger.Error("failed to get brand", zap.Error(err))
 		return err
 	}
-	if !exists {
+	if brand == nil {
 		return fmt.Errorf("brand not found")
 	}
+	if brand.Version != version {
+		return fmt.Errorf("%w: brand is at version %d", entity.ErrVersionMismatch, brand.Version)
+	}
 
-	if err := s.repo.Delete(ctx, id); err != nil {
+	if err := s.repo.Delete(ctx, id, version); err != nil {
 		s.logger.Error("failed to delete brand", zap.Error(err))
 		return err
 	}
+	s.audit.Record(ctx, entity.AuditActionDelete, entity.AuditEntityBrand, id, brand.AuditSnapshot(), nil)
 
 	return nil
 }
 
-func (s *brandService) toResponse(brand *entity.Brand) *entity.BrandResponse {
-	return &entity.BrandResponse{
-		ID:        brand.ID,
-		BrandName: brand.BrandName,
-		CreatedAt: brand.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: brand.UpdatedAt.Format(time.RFC3339),
+func (s *brandService) GetTrash(ctx context.Context, filter entity.TrashFilterRequest) ([]entity.BrandResponse, int64, error) {
+	ctx, span := s.tracer.Start(ctx, "service.brand.GetTrash")
+	defer span.End()
+
+	brands, count, err := s.repo.GetAllTrashed(ctx, filter.ToTrashFilterRepo())
+	if err != nil {
+		s.logger.Error("failed to get trashed brands", zap.Error(err))
+		return nil, 0, err
 	}
+
+	responses := make([]entity.BrandResponse, len(brands))
+	for i, brand := range brands {
+		responses[i] = *s.toResponse(&brand)
+	}
+
+	return responses, count, nil
+}
+
+func (s *brandService) Restore(ctx context.Context, id uuid.UUID) (*entity.BrandResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.brand.Restore")
+	defer span.End()
+
+	brand, err := s.repo.GetTrashedByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get trashed brand", zap.Error(err))
+		return nil, err
+	}
+	if brand == nil {
+		return nil, entity.ErrNotInTrash
+	}
+
+	// Another brand may have taken the name while this one was in the trash
+	exists, err := s.repo.ExistsByName(ctx, brand.BrandName)
+	if err != nil {
+		s.logger.Error("failed to check brand existence", zap.Error(err))
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("%w: %s", entity.ErrRestoreNameTaken, brand.BrandName)
+	}
+
+	if err := s.repo.Restore(ctx, id); err != nil {
+		s.logger.Error("failed to restore brand", zap.Error(err))
+		return nil, err
+	}
+	s.audit.Record(ctx, entity.AuditActionRestore, entity.AuditEntityBrand, id, nil, brand.AuditSnapshot())
+
+	return s.GetByID(ctx, id)
+}
+
+// assignSlug gives the brand the requested slug, or a free one derived from its name when none
+// is requested
+func (s *brandService) assignSlug(ctx context.Context, brand *entity.Brand, requested string) error {
+	slug, err := resolveSlug(ctx, brand.BrandName, requested, entity.SlugEntityBrand, entity.ErrBrandSlugTaken, func(ctx context.Context, slug string) (bool, error) {
+		return s.repo.IsSlugTaken(ctx, slug, brand.ID)
+	})
+	if err != nil {
+		if !errors.Is(err, entity.ErrBrandSlugTaken) {
+			s.logger.Error("failed to assign brand slug", zap.Error(err))
+		}
+		return err
+	}
+	brand.Slug = slug
+	return nil
+}
+
+func (s *brandService) toResponse(brand *entity.Brand) *entity.BrandResponse {
+	return brand.ToResponseDTO()
 }
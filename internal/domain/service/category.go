@@ -0,0 +1,189 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type categoryService struct {
+	repo   entity.CategoryRepository
+	logger *zap.Logger
+	tracer *tracing.Tracer
+}
+
+func NewCategoryService(repo entity.CategoryRepository, logger *zap.Logger, tracer *tracing.Tracer) entity.CategoryService {
+	return &categoryService{
+		repo:   repo,
+		logger: logger,
+		tracer: tracer,
+	}
+}
+
+func (s *categoryService) Create(ctx context.Context, req entity.CreateCategoryRequest) (*entity.CategoryResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.category.Create")
+	defer span.End()
+
+	if err := req.Validate(); err != nil {
+		s.logger.Error("failed to validate category request", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.ensureParentExists(ctx, req.ParentID); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repo.ExistsByName(ctx, req.CategoryName, req.ParentID)
+	if err != nil {
+		s.logger.Error("failed to check category existence", zap.Error(err))
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("category with name %s already exists", req.CategoryName)
+	}
+
+	category := req.ToCategoryEntity()
+	if err := s.repo.Create(ctx, category); err != nil {
+		s.logger.Error("failed to create category", zap.Error(err))
+		return nil, err
+	}
+
+	return category.ToResponseDTO(), nil
+}
+
+func (s *categoryService) GetByID(ctx context.Context, id uuid.UUID) (*entity.CategoryResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.category.GetByID")
+	defer span.End()
+
+	category, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get category", zap.Error(err))
+		return nil, err
+	}
+	if category == nil {
+		return nil, fmt.Errorf("category not found")
+	}
+
+	return category.ToResponseDTO(), nil
+}
+
+func (s *categoryService) GetAll(ctx context.Context, filter entity.CategoryFilterRequest) ([]entity.CategoryResponse, int64, error) {
+	ctx, span := s.tracer.Start(ctx, "service.category.GetAll")
+	defer span.End()
+
+	categories, count, err := s.repo.GetAllWithFilter(ctx, filter.ToCategoryFilterRepo())
+	if err != nil {
+		s.logger.Error("failed to get categories", zap.Error(err))
+		return nil, 0, err
+	}
+
+	responses := make([]entity.CategoryResponse, len(categories))
+	for i, category := range categories {
+		responses[i] = *category.ToResponseDTO()
+	}
+
+	return responses, count, nil
+}
+
+func (s *categoryService) Update(ctx context.Context, id uuid.UUID, req entity.UpdateCategoryRequest) (*entity.CategoryResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.category.Update")
+	defer span.End()
+
+	if err := req.Validate(); err != nil {
+		s.logger.Error("failed to validate category request", zap.Error(err))
+		return nil, err
+	}
+
+	category, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get category", zap.Error(err))
+		return nil, err
+	}
+	if category == nil {
+		return nil, fmt.Errorf("category not found")
+	}
+
+	if err := s.ensureParentExists(ctx, req.ParentID); err != nil {
+		return nil, err
+	}
+
+	// A category cannot become a child of itself or of one of its descendants
+	if req.ParentID != nil {
+		tree, err := s.repo.GetDescendantIDs(ctx, id)
+		if err != nil {
+			s.logger.Error("failed to get category descendants", zap.Error(err))
+			return nil, err
+		}
+		for _, descendantID := range tree {
+			if descendantID == *req.ParentID {
+				return nil, entity.ErrCategoryCycle
+			}
+		}
+	}
+
+	if req.CategoryName != category.CategoryName || !sameParent(req.ParentID, category.ParentID) {
+		exists, err := s.repo.ExistsByName(ctx, req.CategoryName, req.ParentID)
+		if err != nil {
+			s.logger.Error("failed to check category existence", zap.Error(err))
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("category with name %s already exists", req.CategoryName)
+		}
+	}
+
+	category.UpdateFromRequest(req)
+	if err := s.repo.Update(ctx, category); err != nil {
+		s.logger.Error("failed to update category", zap.Error(err))
+		return nil, err
+	}
+
+	return category.ToResponseDTO(), nil
+}
+
+func (s *categoryService) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "service.category.Delete")
+	defer span.End()
+
+	exists, err := s.repo.ExistsByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to check category existence", zap.Error(err))
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("category not found")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete category", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *categoryService) ensureParentExists(ctx context.Context, parentID *uuid.UUID) error {
+	if parentID == nil {
+		return nil
+	}
+
+	exists, err := s.repo.ExistsByID(ctx, *parentID)
+	if err != nil {
+		s.logger.Error("failed to check parent category existence", zap.Error(err))
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("parent category with ID %s not found", *parentID)
+	}
+	return nil
+}
+
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/money"
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"math/big"
+	"strings"
+)
+
+type exchangeRateService struct {
+	repo   entity.ExchangeRateRepository
+	logger *zap.Logger
+	tracer *tracing.Tracer
+}
+
+func NewExchangeRateService(repo entity.ExchangeRateRepository, logger *zap.Logger, tracer *tracing.Tracer) entity.ExchangeRateService {
+	return &exchangeRateService{
+		repo:   repo,
+		logger: logger,
+		tracer: tracer,
+	}
+}
+
+func (s *exchangeRateService) Upload(ctx context.Context, req entity.UploadExchangeRatesRequest) ([]entity.ExchangeRateResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.exchangeRate.Upload")
+	defer span.End()
+
+	base := strings.ToUpper(req.Base)
+	if !money.IsSupported(base) {
+		return nil, fmt.Errorf("%w: %s", entity.ErrUnsupportedCurrency, req.Base)
+	}
+
+	rates := make([]entity.ExchangeRate, 0, len(req.Rates))
+	for quote, value := range req.Rates {
+		quote = strings.ToUpper(quote)
+		if !money.IsSupported(quote) || quote == base {
+			return nil, fmt.Errorf("%w: %s", entity.ErrUnsupportedCurrency, quote)
+		}
+		if _, err := money.ParseRate(value); err != nil {
+			return nil, fmt.Errorf("%w: %s %s", entity.ErrInvalidExchangeRate, quote, value)
+		}
+		rates = append(rates, entity.ExchangeRate{
+			BaseCurrency:  base,
+			QuoteCurrency: quote,
+			Rate:          strings.TrimSpace(value),
+		})
+	}
+
+	if err := s.repo.Upsert(ctx, rates); err != nil {
+		s.logger.Error("failed to upload exchange rates", zap.Error(err))
+		return nil, err
+	}
+
+	return s.GetAll(ctx)
+}
+
+func (s *exchangeRateService) GetAll(ctx context.Context) ([]entity.ExchangeRateResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.exchangeRate.GetAll")
+	defer span.End()
+
+	rates, err := s.repo.GetAll(ctx)
+	if err != nil {
+		s.logger.Error("failed to get exchange rates", zap.Error(err))
+		return nil, err
+	}
+
+	responses := make([]entity.ExchangeRateResponse, len(rates))
+	for i, rate := range rates {
+		responses[i] = *rate.ToResponseDTO()
+	}
+
+	return responses, nil
+}
+
+// exchangeRate finds how many units of to one unit of from is worth, using the inverse rate
+// when only the opposite direction was uploaded
+func exchangeRate(ctx context.Context, repo entity.ExchangeRateRepository, from, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	direct, err := repo.Get(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if direct != nil {
+		return money.ParseRate(direct.Rate)
+	}
+
+	inverse, err := repo.Get(ctx, to, from)
+	if err != nil {
+		return nil, err
+	}
+	if inverse != nil {
+		rate, err := money.ParseRate(inverse.Rate)
+		if err != nil {
+			return nil, err
+		}
+		return rate.Inv(rate), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s to %s", entity.ErrExchangeRateNotFound, from, to)
+}
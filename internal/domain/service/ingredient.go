@@ -0,0 +1,137 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"strings"
+)
+
+type ingredientService struct {
+	repo   entity.IngredientRepository
+	logger *zap.Logger
+	tracer *tracing.Tracer
+}
+
+func NewIngredientService(repo entity.IngredientRepository, logger *zap.Logger, tracer *tracing.Tracer) entity.IngredientService {
+	return &ingredientService{
+		repo:   repo,
+		logger: logger,
+		tracer: tracer,
+	}
+}
+
+func (s *ingredientService) Create(ctx context.Context, req entity.CreateIngredientRequest) (*entity.IngredientResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ingredient.Create")
+	defer span.End()
+
+	if err := req.Validate(); err != nil {
+		s.logger.Error("failed to validate ingredient request", zap.Error(err))
+		return nil, err
+	}
+
+	exists, err := s.repo.ExistsByName(ctx, req.INCIName)
+	if err != nil {
+		s.logger.Error("failed to check ingredient existence", zap.Error(err))
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("ingredient with name %s already exists", req.INCIName)
+	}
+
+	ingredient := req.ToIngredientEntity()
+	if err := s.repo.Create(ctx, ingredient); err != nil {
+		s.logger.Error("failed to create ingredient", zap.Error(err))
+		return nil, err
+	}
+
+	return ingredient.ToResponseDTO(), nil
+}
+
+func (s *ingredientService) GetByID(ctx context.Context, id uuid.UUID) (*entity.IngredientResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ingredient.GetByID")
+	defer span.End()
+
+	ingredient, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get ingredient", zap.Error(err))
+		return nil, err
+	}
+	if ingredient == nil {
+		return nil, fmt.Errorf("ingredient not found")
+	}
+
+	return ingredient.ToResponseDTO(), nil
+}
+
+func (s *ingredientService) GetAll(ctx context.Context, filter entity.IngredientFilterRequest) ([]entity.IngredientResponse, int64, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ingredient.GetAll")
+	defer span.End()
+
+	ingredients, count, err := s.repo.GetAllWithFilter(ctx, filter.ToIngredientFilterRepo())
+	if err != nil {
+		s.logger.Error("failed to get ingredients", zap.Error(err))
+		return nil, 0, err
+	}
+
+	responses := make([]entity.IngredientResponse, len(ingredients))
+	for i, ingredient := range ingredients {
+		responses[i] = *ingredient.ToResponseDTO()
+	}
+
+	return responses, count, nil
+}
+
+func (s *ingredientService) Update(ctx context.Context, id uuid.UUID, req entity.UpdateIngredientRequest) (*entity.IngredientResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ingredient.Update")
+	defer span.End()
+
+	if err := req.Validate(); err != nil {
+		s.logger.Error("failed to validate ingredient request", zap.Error(err))
+		return nil, err
+	}
+
+	ingredient, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get ingredient", zap.Error(err))
+		return nil, err
+	}
+	if ingredient == nil {
+		return nil, fmt.Errorf("ingredient not found")
+	}
+
+	// INCI names are unique regardless of case
+	if !strings.EqualFold(req.INCIName, ingredient.INCIName) {
+		exists, err := s.repo.ExistsByName(ctx, req.INCIName)
+		if err != nil {
+			s.logger.Error("failed to check ingredient existence", zap.Error(err))
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("ingredient with name %s already exists", req.INCIName)
+		}
+	}
+
+	ingredient.UpdateFromRequest(req)
+	if err := s.repo.Update(ctx, ingredient); err != nil {
+		s.logger.Error("failed to update ingredient", zap.Error(err))
+		return nil, err
+	}
+
+	return ingredient.ToResponseDTO(), nil
+}
+
+func (s *ingredientService) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "service.ingredient.Delete")
+	defer span.End()
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete ingredient", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/money"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"math/big"
+	"strings"
+)
+
+type priceListService struct {
+	repo         entity.PriceListRepository
+	productRepo  entity.ProductRepository
+	exchangeRepo entity.ExchangeRateRepository
+	logger       *zap.Logger
+	tracer       *tracing.Tracer
+}
+
+func NewPriceListService(
+	repo entity.PriceListRepository,
+	productRepo entity.ProductRepository,
+	exchangeRepo entity.ExchangeRateRepository,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) entity.PriceListService {
+	return &priceListService{
+		repo:         repo,
+		productRepo:  productRepo,
+		exchangeRepo: exchangeRepo,
+		logger:       logger,
+		tracer:       tracer,
+	}
+}
+
+func (s *priceListService) SetPrice(ctx context.Context, productID uuid.UUID, req entity.SetPriceListItemRequest) (*entity.PriceListItemResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.priceList.SetPrice")
+	defer span.End()
+
+	if err := s.ensureProductExists(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	if !money.IsSupported(req.Price.Currency) {
+		return nil, fmt.Errorf("%w: %s", entity.ErrUnsupportedCurrency, req.Price.Currency)
+	}
+	if !req.Price.IsPositive() {
+		return nil, entity.ErrInvalidPrice
+	}
+
+	item := &entity.PriceListItem{
+		ProductID: productID,
+		Price:     req.Price,
+	}
+	if err := s.repo.Upsert(ctx, item); err != nil {
+		s.logger.Error("failed to set price list item", zap.Error(err))
+		return nil, err
+	}
+
+	return item.ToResponseDTO(), nil
+}
+
+func (s *priceListService) GetAll(ctx context.Context, productID uuid.UUID) ([]entity.PriceListItemResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.priceList.GetAll")
+	defer span.End()
+
+	if err := s.ensureProductExists(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.GetAllByProductID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get price list items", zap.Error(err))
+		return nil, err
+	}
+
+	responses := make([]entity.PriceListItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = *item.ToResponseDTO()
+	}
+
+	return responses, nil
+}
+
+func (s *priceListService) Delete(ctx context.Context, productID uuid.UUID, currency string) error {
+	ctx, span := s.tracer.Start(ctx, "service.priceList.Delete")
+	defer span.End()
+
+	if err := s.repo.Delete(ctx, productID, strings.ToUpper(currency)); err != nil {
+		s.logger.Error("failed to delete price list item", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// ApplyCurrency rewrites the prices of the given products into currency, preferring the
+// product's explicit price list entry and converting with the exchange rates otherwise
+func (s *priceListService) ApplyCurrency(ctx context.Context, currency string, products ...*entity.ProductResponse) error {
+	ctx, span := s.tracer.Start(ctx, "service.priceList.ApplyCurrency")
+	defer span.End()
+
+	currency = strings.ToUpper(currency)
+	if !money.IsSupported(currency) {
+		return fmt.Errorf("%w: %s", entity.ErrUnsupportedCurrency, currency)
+	}
+
+	productIDs := make([]uuid.UUID, len(products))
+	for i, product := range products {
+		productIDs[i] = product.ID
+	}
+
+	items, err := s.repo.GetByProductIDs(ctx, productIDs, currency)
+	if err != nil {
+		s.logger.Error("failed to get price list items", zap.Error(err))
+		return err
+	}
+
+	listed := make(map[uuid.UUID]money.Money, len(items))
+	for _, item := range items {
+		listed[item.ProductID] = item.Price
+	}
+
+	// Most products share a currency, so each rate is only looked up once
+	rates := make(map[string]*big.Rat)
+	convert := func(price money.Money) (money.Money, error) {
+		rate, ok := rates[price.Currency]
+		if !ok {
+			rate, err = exchangeRate(ctx, s.exchangeRepo, price.Currency, currency)
+			if err != nil {
+				return money.Money{}, err
+			}
+			rates[price.Currency] = rate
+		}
+		return price.Convert(rate, currency)
+	}
+
+	for _, product := range products {
+		original := product.Price
+		if price, ok := listed[product.ID]; ok {
+			product.Price = price
+		} else if product.Price, err = convert(product.Price); err != nil {
+			s.logger.Error("failed to convert product price", zap.Error(err))
+			return err
+		}
+		if err := rescalePromotions(product, original); err != nil {
+			s.logger.Error("failed to convert promotion discounts", zap.Error(err))
+			return err
+		}
+
+		for i := range product.Variants {
+			if product.Variants[i].Price, err = convert(product.Variants[i].Price); err != nil {
+				s.logger.Error("failed to convert product variant price", zap.Error(err))
+				return err
+			}
+		}
+
+		if len(product.Variants) == 0 {
+			product.PriceRange = entity.PriceRange{Min: product.Price, Max: product.Price}
+			continue
+		}
+		if product.PriceRange.Min, err = convert(product.PriceRange.Min); err != nil {
+			return err
+		}
+		if product.PriceRange.Max, err = convert(product.PriceRange.Max); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rescalePromotions moves the applied discounts to the new product price, each keeping its share
+// of the original price, so the final price still equals the price minus its discounts
+func rescalePromotions(product *entity.ProductResponse, original money.Money) error {
+	product.FinalPrice = product.Price
+	if !original.IsPositive() {
+		product.AppliedPromotions = nil
+		return nil
+	}
+
+	for i := range product.AppliedPromotions {
+		promotion := &product.AppliedPromotions[i]
+		discount, err := product.Price.Scale(big.NewRat(promotion.Discount.Amount, original.Amount))
+		if err != nil {
+			return err
+		}
+		if discount.Amount > product.FinalPrice.Amount {
+			discount.Amount = product.FinalPrice.Amount
+		}
+
+		promotion.Discount = discount
+		product.FinalPrice.Amount -= discount.Amount
+	}
+	return nil
+}
+
+func (s *priceListService) ensureProductExists(ctx context.Context, productID uuid.UUID) error {
+	exists, err := s.productRepo.ExistsByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to check product existence", zap.Error(err))
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("product not found")
+	}
+	return nil
+}
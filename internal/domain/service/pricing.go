@@ -0,0 +1,159 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/money"
+	"context"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"time"
+)
+
+type pricingEvaluator struct {
+	promotionRepo entity.PromotionRepository
+	categoryRepo  entity.CategoryRepository
+	logger        *zap.Logger
+	tracer        *tracing.Tracer
+}
+
+func NewPricingEvaluator(
+	promotionRepo entity.PromotionRepository,
+	categoryRepo entity.CategoryRepository,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) entity.PricingEvaluator {
+	return &pricingEvaluator{
+		promotionRepo: promotionRepo,
+		categoryRepo:  categoryRepo,
+		logger:        logger,
+		tracer:        tracer,
+	}
+}
+
+// Evaluate applies the promotions running at the given time to each product price. Promotions
+// are taken by priority: the first one that applies always does, later ones only when both it
+// and they are stackable. Every discount is taken off the price left by the previous ones
+func (s *pricingEvaluator) Evaluate(ctx context.Context, at time.Time, products ...*entity.ProductResponse) error {
+	ctx, span := s.tracer.Start(ctx, "service.pricing.Evaluate")
+	defer span.End()
+
+	for _, product := range products {
+		product.FinalPrice = product.Price
+		product.AppliedPromotions = nil
+	}
+
+	promotions, err := s.promotionRepo.GetActive(ctx, at)
+	if err != nil {
+		s.logger.Error("failed to get active promotions", zap.Error(err))
+		return err
+	}
+	if len(promotions) == 0 {
+		return nil
+	}
+
+	// A category promotion also covers the products of its subcategories
+	categoryScopes := make(map[uuid.UUID]map[uuid.UUID]struct{})
+	for _, promotion := range promotions {
+		if promotion.ScopeType != entity.PromotionScopeCategory {
+			continue
+		}
+		if _, ok := categoryScopes[promotion.ScopeID]; ok {
+			continue
+		}
+
+		ids, err := s.categoryRepo.GetDescendantIDs(ctx, promotion.ScopeID)
+		if err != nil {
+			s.logger.Error("failed to get promotion category descendants", zap.Error(err))
+			return err
+		}
+		scope := make(map[uuid.UUID]struct{}, len(ids))
+		for _, id := range ids {
+			scope[id] = struct{}{}
+		}
+		categoryScopes[promotion.ScopeID] = scope
+	}
+
+	for _, product := range products {
+		for _, promotion := range promotions {
+			if !promotionCovers(&promotion, product, categoryScopes) {
+				continue
+			}
+			if len(product.AppliedPromotions) > 0 && !promotion.Stackable {
+				continue
+			}
+
+			discount, ok, err := promotionDiscount(&promotion, product.FinalPrice)
+			if err != nil {
+				s.logger.Error("failed to compute promotion discount", zap.Error(err))
+				return err
+			}
+			if !ok {
+				continue
+			}
+
+			product.FinalPrice.Amount -= discount.Amount
+			product.AppliedPromotions = append(product.AppliedPromotions, entity.AppliedPromotionResponse{
+				ID:            promotion.ID,
+				PromotionName: promotion.PromotionName,
+				DiscountType:  promotion.DiscountType,
+				Discount:      discount,
+			})
+
+			if !promotion.Stackable {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func promotionCovers(promotion *entity.Promotion, product *entity.ProductResponse, categoryScopes map[uuid.UUID]map[uuid.UUID]struct{}) bool {
+	switch promotion.ScopeType {
+	case entity.PromotionScopeProduct:
+		return promotion.ScopeID == product.ID
+	case entity.PromotionScopeBrand:
+		return promotion.ScopeID == product.BrandID
+	case entity.PromotionScopeCategory:
+		scope := categoryScopes[promotion.ScopeID]
+		for _, category := range product.Categories {
+			if _, ok := scope[category.ID]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// promotionDiscount returns the amount the promotion takes off price, capped at the price itself.
+// A fixed discount only applies to prices in its own currency
+func promotionDiscount(promotion *entity.Promotion, price money.Money) (money.Money, bool, error) {
+	if !price.IsPositive() {
+		return money.Money{}, false, nil
+	}
+
+	var discount money.Money
+	switch promotion.DiscountType {
+	case entity.DiscountTypePercentage:
+		rate, err := promotion.PercentageRate()
+		if err != nil {
+			return money.Money{}, false, err
+		}
+		if discount, err = price.Scale(rate); err != nil {
+			return money.Money{}, false, err
+		}
+	case entity.DiscountTypeFixed:
+		if promotion.AmountOff.Currency != price.Currency {
+			return money.Money{}, false, nil
+		}
+		discount = promotion.AmountOff
+	default:
+		return money.Money{}, false, nil
+	}
+
+	if discount.Amount > price.Amount {
+		discount.Amount = price.Amount
+	}
+	return discount, discount.IsPositive(), nil
+}
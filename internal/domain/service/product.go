@@ -4,6 +4,7 @@ import (
 	"Unnispick/internal/domain/entity"
 	"Unnispick/internal/infra/tracing"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -11,18 +12,44 @@ import (
 )
 
 type productService struct {
-	repo      entity.ProductRepository
-	brandRepo entity.BrandRepository
-	logger    *zap.Logger
-	tracer    *tracing.Tracer
+	repo           entity.ProductRepository
+	brandRepo      entity.BrandRepository
+	categoryRepo   entity.CategoryRepository
+	tagRepo        entity.TagRepository
+	ingredientRepo entity.IngredientRepository
+	movementRepo   entity.StockMovementRepository
+	pricing        entity.PricingEvaluator
+	redirectRepo   entity.SlugRedirectRepository
+	audit          entity.AuditService
+	logger         *zap.Logger
+	tracer         *tracing.Tracer
 }
 
-func NewProductService(repo entity.ProductRepository, brandRepo entity.BrandRepository, logger *zap.Logger, tracer *tracing.Tracer) entity.ProductService {
+func NewProductService(
+	repo entity.ProductRepository,
+	brandRepo entity.BrandRepository,
+	categoryRepo entity.CategoryRepository,
+	tagRepo entity.TagRepository,
+	ingredientRepo entity.IngredientRepository,
+	movementRepo entity.StockMovementRepository,
+	pricing entity.PricingEvaluator,
+	redirectRepo entity.SlugRedirectRepository,
+	audit entity.AuditService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) entity.ProductService {
 	return &productService{
-		repo:      repo,
-		brandRepo: brandRepo,
-		logger:    logger,
-		tracer:    tracer,
+		repo:           repo,
+		brandRepo:      brandRepo,
+		categoryRepo:   categoryRepo,
+		tagRepo:        tagRepo,
+		ingredientRepo: ingredientRepo,
+		movementRepo:   movementRepo,
+		pricing:        pricing,
+		redirectRepo:   redirectRepo,
+		audit:          audit,
+		logger:         logger,
+		tracer:         tracer,
 	}
 }
 
@@ -50,13 +77,34 @@ func (s *productService) Create(ctx context.Context, req entity.CreateProductReq
 		return nil, fmt.Errorf("product with name %s already exists", req.ProductName)
 	}
 
+	req.CategoryIDs = uniqueIDs(req.CategoryIDs)
+	if err := s.ensureCategoriesExist(ctx, req.CategoryIDs); err != nil {
+		return nil, err
+	}
+
 	product := req.ToProductEntity()
+	if err := s.assignSlug(ctx, product, req.Slug); err != nil {
+		return nil, err
+	}
+
+	product.Tags, err = s.tagRepo.FindOrCreateByNames(ctx, entity.NormalizeTagNames(req.Tags))
+	if err != nil {
+		s.logger.Error("failed to resolve product tags", zap.Error(err))
+		return nil, err
+	}
+
 	if err := s.repo.Create(ctx, product); err != nil {
 		s.logger.Error("failed to create product", zap.Error(err))
 		return nil, err
 	}
+	s.audit.Record(ctx, entity.AuditActionCreate, entity.AuditEntityProduct, product.ID, nil, product.AuditSnapshot())
 
-	return s.toResponse(product), nil
+	// Reload so assigned categories are returned with their names
+	if len(req.CategoryIDs) > 0 {
+		return s.GetByID(ctx, product.ID)
+	}
+
+	return s.priced(ctx, s.toResponse(product))
 }
 
 func (s *productService) GetByID(ctx context.Context, id uuid.UUID) (*entity.ProductResponse, error) {
@@ -72,7 +120,39 @@ func (s *productService) GetByID(ctx context.Context, id uuid.UUID) (*entity.Pro
 		return nil, fmt.Errorf("product not found")
 	}
 
-	return s.toResponse(product), nil
+	return s.priced(ctx, s.toResponse(product))
+}
+
+func (s *productService) GetBySlug(ctx context.Context, slug string) (*entity.ProductResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.GetBySlug")
+	defer span.End()
+
+	product, err := s.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		s.logger.Error("failed to get product by slug", zap.Error(err))
+		return nil, err
+	}
+	if product != nil {
+		return s.priced(ctx, s.toResponse(product))
+	}
+
+	redirect, err := s.redirectRepo.GetBySlug(ctx, entity.SlugEntityProduct, slug)
+	if err != nil {
+		s.logger.Error("failed to get product slug redirect", zap.Error(err))
+		return nil, err
+	}
+	if redirect != nil {
+		product, err = s.repo.GetByID(ctx, redirect.EntityID)
+		if err != nil {
+			s.logger.Error("failed to get product", zap.Error(err))
+			return nil, err
+		}
+		if product != nil {
+			return nil, &entity.SlugMovedError{Slug: product.Slug}
+		}
+	}
+
+	return nil, fmt.Errorf("product not found")
 }
 
 func (s *productService) GetAll(ctx context.Context, filter entity.ProductFilterRequest) ([]entity.ProductResponse, int64, error) {
@@ -86,14 +166,21 @@ func (s *productService) GetAll(ctx context.Context, filter entity.ProductFilter
 	}
 
 	responses := make([]entity.ProductResponse, len(products))
+	priced := make([]*entity.ProductResponse, len(products))
 	for i, product := range products {
 		responses[i] = *s.toResponse(&product)
+		priced[i] = &responses[i]
+	}
+
+	if err := s.pricing.Evaluate(ctx, time.Now(), priced...); err != nil {
+		s.logger.Error("failed to evaluate product prices", zap.Error(err))
+		return nil, 0, err
 	}
 
 	return responses, count, nil
 }
 
-func (s *productService) Update(ctx context.Context, id uuid.UUID, req entity.UpdateProductRequest) (*entity.ProductResponse, error) {
+func (s *productService) Update(ctx context.Context, id uuid.UUID, version int, req entity.UpdateProductRequest) (*entity.ProductResponse, error) {
 	ctx, span := s.tracer.Start(ctx, "service.product.Update")
 	defer span.End()
 
@@ -106,6 +193,9 @@ func (s *productService) Update(ctx context.Context, id uuid.UUID, req entity.Up
 	if product == nil {
 		return nil, fmt.Errorf("product not found")
 	}
+	if product.Version != version {
+		return nil, fmt.Errorf("%w: product is at version %d", entity.ErrVersionMismatch, product.Version)
+	}
 
 	// Check if brand exists if brand ID is being updated
 	if req.BrandID != uuid.Nil && req.BrandID != product.BrandID {
@@ -119,6 +209,11 @@ func (s *productService) Update(ctx context.Context, id uuid.UUID, req entity.Up
 		}
 	}
 
+	// Variants are priced in the product currency, so it cannot change underneath them
+	if len(product.Variants) > 0 && req.Price.Currency != product.Price.Currency {
+		return nil, entity.ErrCurrencyMismatch
+	}
+
 	// Check if new name conflicts with existing product
 	if req.ProductName != product.ProductName {
 		exists, err := s.repo.ExistsByName(ctx, req.ProductName)
@@ -131,54 +226,544 @@ func (s *productService) Update(ctx context.Context, id uuid.UUID, req entity.Up
 		}
 	}
 
+	// A nil category list keeps the current assignment, an empty one clears it
+	if req.CategoryIDs != nil {
+		req.CategoryIDs = uniqueIDs(req.CategoryIDs)
+		if err := s.ensureCategoriesExist(ctx, req.CategoryIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	before := product.AuditSnapshot()
+	renamed := product.ProductName != req.ProductName
 	product.UpdateFromRequest(req)
+
+	// A rename moves the product to a slug of its new name unless one is given
+	if req.Slug != "" || renamed {
+		if err := s.assignSlug(ctx, product, req.Slug); err != nil {
+			return nil, err
+		}
+	}
+
+	// The repository replaces the categories and tags with the ones the product carries, so the
+	// loaded ones are kept unless the request sets them
+	if req.CategoryIDs != nil {
+		product.Categories = make([]entity.Category, len(req.CategoryIDs))
+		for i, categoryID := range req.CategoryIDs {
+			product.Categories[i] = entity.Category{ID: categoryID}
+		}
+	}
+
+	// Tags follow the same rule, nil keeps them and an empty list clears them
+	if req.Tags != nil {
+		product.Tags, err = s.tagRepo.FindOrCreateByNames(ctx, entity.NormalizeTagNames(req.Tags))
+		if err != nil {
+			s.logger.Error("failed to resolve product tags", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	if err := s.repo.Update(ctx, product); err != nil {
 		s.logger.Error("failed to update product", zap.Error(err))
 		return nil, err
 	}
 
-	return s.toResponse(product), nil
+	s.audit.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityProduct, product.ID, before, product.AuditSnapshot())
+
+	if req.CategoryIDs != nil || req.Tags != nil {
+		return s.GetByID(ctx, product.ID)
+	}
+
+	return s.priced(ctx, s.toResponse(product))
 }
 
-func (s *productService) Delete(ctx context.Context, id uuid.UUID) error {
+func (s *productService) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	ctx, span := s.tracer.Start(ctx, "service.product.Delete")
 	defer span.End()
 
-	exists, err := s.repo.ExistsByID(ctx, id)
+	product, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		s.logger.Error("failed to check product existence", zap.Error(err))
+		s.logger.Error("failed to get product", zap.Error(err))
 		return err
 	}
-	if !exists {
+	if product == nil {
 		return fmt.Errorf("product not found")
 	}
+	if product.Version != version {
+		return fmt.Errorf("%w: product is at version %d", entity.ErrVersionMismatch, product.Version)
+	}
+
+	// Bundles would lose part of their contents
+	inBundle, err := s.repo.IsBundleComponent(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to check bundle membership", zap.Error(err))
+		return err
+	}
+	if inBundle {
+		return entity.ErrProductInBundle
+	}
 
-	if err := s.repo.Delete(ctx, id); err != nil {
+	if err := s.repo.Delete(ctx, id, version); err != nil {
 		s.logger.Error("failed to delete product", zap.Error(err))
 		return err
 	}
+	s.audit.Record(ctx, entity.AuditActionDelete, entity.AuditEntityProduct, id, product.AuditSnapshot(), nil)
 
 	return nil
 }
 
+func (s *productService) IncreaseStock(ctx context.Context, id uuid.UUID, req entity.StockAdjustmentRequest) (*entity.ProductResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.IncreaseStock")
+	defer span.End()
+
+	if err := req.Validate(); err != nil {
+		s.logger.Error("failed to validate stock adjustment request", zap.Error(err))
+		return nil, err
+	}
+
+	quantity, err := s.repo.IncreaseStock(ctx, id, req.Amount, req.ToStockMovementNote(entity.StockReasonIncrease))
+	if err != nil {
+		s.logger.Error("failed to increase product stock", zap.Error(err))
+		return nil, err
+	}
+	s.recordStockAudit(ctx, entity.AuditActionStockIncrease, id, quantity-req.Amount, quantity)
+
+	return s.GetByID(ctx, id)
+}
+
+func (s *productService) DecreaseStock(ctx context.Context, id uuid.UUID, req entity.StockAdjustmentRequest) (*entity.ProductResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.DecreaseStock")
+	defer span.End()
+
+	if err := req.Validate(); err != nil {
+		s.logger.Error("failed to validate stock adjustment request", zap.Error(err))
+		return nil, err
+	}
+
+	quantity, err := s.repo.DecreaseStock(ctx, id, req.Amount, req.ToStockMovementNote(entity.StockReasonDecrease))
+	if err != nil {
+		s.logger.Error("failed to decrease product stock", zap.Error(err))
+		return nil, err
+	}
+
+	response, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// A bundle holds no stock of its own, the sale shows in the stock movements of its components
+	if response.ProductType != entity.ProductTypeBundle {
+		s.recordStockAudit(ctx, entity.AuditActionStockDecrease, id, quantity+req.Amount, quantity)
+	}
+
+	return response, nil
+}
+
+func (s *productService) GetStockMovements(ctx context.Context, id uuid.UUID, filter entity.StockMovementFilterRequest) ([]entity.StockMovementResponse, int64, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.GetStockMovements")
+	defer span.End()
+
+	exists, err := s.repo.ExistsByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to check product existence", zap.Error(err))
+		return nil, 0, err
+	}
+	if !exists {
+		return nil, 0, fmt.Errorf("product not found")
+	}
+
+	movements, count, err := s.movementRepo.GetAllByProductWithFilter(ctx, id, filter.ToStockMovementFilterRepo())
+	if err != nil {
+		s.logger.Error("failed to get stock movements", zap.Error(err))
+		return nil, 0, err
+	}
+
+	responses := make([]entity.StockMovementResponse, len(movements))
+	for i, movement := range movements {
+		responses[i] = *movement.ToResponseDTO()
+	}
+
+	return responses, count, nil
+}
+
+func (s *productService) SetIngredients(ctx context.Context, id uuid.UUID, req entity.SetProductIngredientsRequest) (*entity.ProductResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.SetIngredients")
+	defer span.End()
+
+	product, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get product", zap.Error(err))
+		return nil, err
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+
+	// The list order is the INCI position, so a repeated ingredient is ambiguous rather than redundant
+	if len(uniqueIDs(req.IngredientIDs)) != len(req.IngredientIDs) {
+		return nil, entity.ErrDuplicateIngredient
+	}
+
+	if len(req.IngredientIDs) > 0 {
+		count, err := s.ingredientRepo.CountByIDs(ctx, req.IngredientIDs)
+		if err != nil {
+			s.logger.Error("failed to check ingredient existence", zap.Error(err))
+			return nil, err
+		}
+		if count != int64(len(req.IngredientIDs)) {
+			return nil, fmt.Errorf("one or more ingredients not found")
+		}
+	}
+
+	before := product.AuditSnapshot()
+	if err := s.repo.ReplaceIngredients(ctx, id, req.IngredientIDs); err != nil {
+		s.logger.Error("failed to replace product ingredients", zap.Error(err))
+		return nil, err
+	}
+	product.Ingredients = make([]entity.ProductIngredient, len(req.IngredientIDs))
+	for i, ingredientID := range req.IngredientIDs {
+		product.Ingredients[i] = entity.ProductIngredient{ProductID: id, IngredientID: ingredientID, Position: i + 1}
+	}
+	s.audit.Record(ctx, entity.AuditActionSetIngredients, entity.AuditEntityProduct, id, before, product.AuditSnapshot())
+
+	return s.GetByID(ctx, id)
+}
+
+func (s *productService) CreateBundle(ctx context.Context, req entity.CreateBundleRequest) (*entity.ProductResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.CreateBundle")
+	defer span.End()
+
+	// Check if brand exists
+	exists, err := s.brandRepo.ExistsByID(ctx, req.BrandID)
+	if err != nil {
+		s.logger.Error("failed to check brand existence", zap.Error(err))
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("brand with ID %s not found", req.BrandID)
+	}
+
+	// Check if product name already exists
+	exists, err = s.repo.ExistsByName(ctx, req.ProductName)
+	if err != nil {
+		s.logger.Error("failed to check product existence", zap.Error(err))
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("product with name %s already exists", req.ProductName)
+	}
+
+	req.CategoryIDs = uniqueIDs(req.CategoryIDs)
+	if err := s.ensureCategoriesExist(ctx, req.CategoryIDs); err != nil {
+		return nil, err
+	}
+
+	product := req.ToProductEntity()
+	if err := s.ensureBundleComponents(ctx, product.Components); err != nil {
+		return nil, err
+	}
+
+	if err := s.assignSlug(ctx, product, req.Slug); err != nil {
+		return nil, err
+	}
+
+	product.Tags, err = s.tagRepo.FindOrCreateByNames(ctx, entity.NormalizeTagNames(req.Tags))
+	if err != nil {
+		s.logger.Error("failed to resolve product tags", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, product); err != nil {
+		s.logger.Error("failed to create bundle", zap.Error(err))
+		return nil, err
+	}
+	s.audit.Record(ctx, entity.AuditActionCreate, entity.AuditEntityProduct, product.ID, nil, product.AuditSnapshot())
+
+	// Reload so the availability is derived from the component stock
+	return s.GetByID(ctx, product.ID)
+}
+
+func (s *productService) SetBundleComponents(ctx context.Context, id uuid.UUID, req entity.SetBundleComponentsRequest) (*entity.ProductResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.SetBundleComponents")
+	defer span.End()
+
+	product, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get product", zap.Error(err))
+		return nil, err
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+	if !product.IsBundle() {
+		return nil, entity.ErrNotBundle
+	}
+
+	components := req.ToBundleComponents()
+	if err := s.ensureBundleComponents(ctx, components); err != nil {
+		return nil, err
+	}
+
+	before := product.AuditSnapshot()
+	if err := s.repo.ReplaceBundleComponents(ctx, id, components); err != nil {
+		s.logger.Error("failed to replace bundle components", zap.Error(err))
+		return nil, err
+	}
+	product.Components = components
+	s.audit.Record(ctx, entity.AuditActionSetComponents, entity.AuditEntityProduct, id, before, product.AuditSnapshot())
+
+	return s.GetByID(ctx, id)
+}
+
+func (s *productService) Publish(ctx context.Context, id uuid.UUID) (*entity.ProductResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.Publish")
+	defer span.End()
+
+	return s.transition(ctx, id, entity.ProductStatusActive, entity.AuditActionPublish)
+}
+
+func (s *productService) Discontinue(ctx context.Context, id uuid.UUID) (*entity.ProductResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.Discontinue")
+	defer span.End()
+
+	return s.transition(ctx, id, entity.ProductStatusDiscontinued, entity.AuditActionDiscontinue)
+}
+
+func (s *productService) Archive(ctx context.Context, id uuid.UUID) (*entity.ProductResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.Archive")
+	defer span.End()
+
+	return s.transition(ctx, id, entity.ProductStatusArchived, entity.AuditActionArchive)
+}
+
+func (s *productService) GetTrash(ctx context.Context, filter entity.TrashFilterRequest) ([]entity.ProductResponse, int64, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.GetTrash")
+	defer span.End()
+
+	products, count, err := s.repo.GetAllTrashed(ctx, filter.ToTrashFilterRepo())
+	if err != nil {
+		s.logger.Error("failed to get trashed products", zap.Error(err))
+		return nil, 0, err
+	}
+
+	responses := make([]entity.ProductResponse, len(products))
+	for i, product := range products {
+		responses[i] = *s.toResponse(&product)
+	}
+
+	return responses, count, nil
+}
+
+func (s *productService) Restore(ctx context.Context, id uuid.UUID) (*entity.ProductResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.Restore")
+	defer span.End()
+
+	product, err := s.repo.GetTrashedByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get trashed product", zap.Error(err))
+		return nil, err
+	}
+	if product == nil {
+		return nil, entity.ErrNotInTrash
+	}
+
+	exists, err := s.brandRepo.ExistsByID(ctx, product.BrandID)
+	if err != nil {
+		s.logger.Error("failed to check brand existence", zap.Error(err))
+		return nil, err
+	}
+	if !exists {
+		return nil, entity.ErrRestoreBrandTrashed
+	}
+
+	// Another product may have taken the name while this one was in the trash
+	exists, err = s.repo.ExistsByName(ctx, product.ProductName)
+	if err != nil {
+		s.logger.Error("failed to check product existence", zap.Error(err))
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("%w: %s", entity.ErrRestoreNameTaken, product.ProductName)
+	}
+
+	if err := s.repo.Restore(ctx, id); err != nil {
+		s.logger.Error("failed to restore product", zap.Error(err))
+		return nil, err
+	}
+	s.audit.Record(ctx, entity.AuditActionRestore, entity.AuditEntityProduct, id, nil, product.AuditSnapshot())
+
+	return s.GetByID(ctx, id)
+}
+
+// transition moves a product along its lifecycle, rejecting moves the state machine does not allow
+func (s *productService) transition(ctx context.Context, id uuid.UUID, status, action string) (*entity.ProductResponse, error) {
+	product, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get product", zap.Error(err))
+		return nil, err
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+
+	if !product.CanTransitionTo(status) {
+		return nil, fmt.Errorf("%w: %s to %s", entity.ErrInvalidStatusTransition, product.Status, status)
+	}
+
+	if err := s.repo.UpdateStatus(ctx, id, product.Status, status); err != nil {
+		s.logger.Error("failed to update product status", zap.Error(err))
+		return nil, err
+	}
+
+	before := product.AuditSnapshot()
+	product.Status = status
+	product.Version++
+	s.audit.Record(ctx, action, entity.AuditEntityProduct, id, before, product.AuditSnapshot())
+
+	return s.priced(ctx, s.toResponse(product))
+}
+
+// recordStockAudit records a stock adjustment as the change of the product quantity alone, the
+// rest of the product is untouched by it
+func (s *productService) recordStockAudit(ctx context.Context, action string, id uuid.UUID, before, after int) {
+	s.audit.Record(ctx, action, entity.AuditEntityProduct, id,
+		entity.AuditSnapshot{"quantity": before},
+		entity.AuditSnapshot{"quantity": after},
+	)
+}
+
+// ensureBundleComponents checks every component is listed once and is an existing product that
+// is not a bundle itself, which also keeps a bundle from containing itself
+func (s *productService) ensureBundleComponents(ctx context.Context, components []entity.BundleComponent) error {
+	ids, err := entity.BundleComponentIDs(components)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountSimpleByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("failed to check bundle component existence", zap.Error(err))
+		return err
+	}
+	if count != int64(len(ids)) {
+		return entity.ErrInvalidBundleComponent
+	}
+	return nil
+}
+
+// assignSlug gives the product the requested slug, or a free one derived from its name when none
+// is requested
+func (s *productService) assignSlug(ctx context.Context, product *entity.Product, requested string) error {
+	return s.assignSlugWith(ctx, product, requested, func(ctx context.Context, slug string) (bool, error) {
+		return s.repo.IsSlugTaken(ctx, slug, product.ID)
+	})
+}
+
+// assignSlugWith is assignSlug with the availability check supplied by the caller
+func (s *productService) assignSlugWith(ctx context.Context, product *entity.Product, requested string, taken func(ctx context.Context, slug string) (bool, error)) error {
+	slug, err := resolveSlug(ctx, product.ProductName, requested, entity.SlugEntityProduct, entity.ErrProductSlugTaken, taken)
+	if err != nil {
+		if !errors.Is(err, entity.ErrProductSlugTaken) {
+			s.logger.Error("failed to assign product slug", zap.Error(err))
+		}
+		return err
+	}
+	product.Slug = slug
+	return nil
+}
+
+func (s *productService) ensureCategoriesExist(ctx context.Context, categoryIDs []uuid.UUID) error {
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	count, err := s.categoryRepo.CountByIDs(ctx, categoryIDs)
+	if err != nil {
+		s.logger.Error("failed to check category existence", zap.Error(err))
+		return err
+	}
+	if count != int64(len(categoryIDs)) {
+		return fmt.Errorf("one or more categories not found")
+	}
+	return nil
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	if ids == nil {
+		return nil
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// priced fills in the final price of a product response from the promotions running now
+func (s *productService) priced(ctx context.Context, response *entity.ProductResponse) (*entity.ProductResponse, error) {
+	if err := s.pricing.Evaluate(ctx, time.Now(), response); err != nil {
+		s.logger.Error("failed to evaluate product price", zap.Error(err))
+		return nil, err
+	}
+	return response, nil
+}
+
 func (s *productService) toResponse(product *entity.Product) *entity.ProductResponse {
 	response := &entity.ProductResponse{
-		ID:          product.ID,
-		ProductName: product.ProductName,
-		Price:       product.Price,
-		Quantity:    product.Quantity,
-		BrandID:     product.BrandID,
-		CreatedAt:   product.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   product.UpdatedAt.Format(time.RFC3339),
+		ID:                product.ID,
+		ProductName:       product.ProductName,
+		Slug:              product.Slug,
+		ProductType:       product.ProductType,
+		Status:            product.Status,
+		Price:             product.Price,
+		FinalPrice:        product.Price,
+		Quantity:          product.Quantity,
+		ReservedQuantity:  product.ReservedQuantity,
+		AvailableQuantity: product.AvailableQuantity(),
+		PriceRange:        product.PriceRange(),
+		TotalStock:        product.TotalStock(),
+		BrandID:           product.BrandID,
+		Version:           product.Version,
+		CreatedAt:         product.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         product.UpdatedAt.Format(time.RFC3339),
+		Highlights:        product.SearchHighlights(),
+	}
+
+	if product.DeletedAt.Valid {
+		response.DeletedAt = product.DeletedAt.Time.Format(time.RFC3339)
 	}
 
 	if product.Brand != nil {
-		response.Brand = &entity.BrandResponse{
-			ID:        product.Brand.ID,
-			BrandName: product.Brand.BrandName,
-			CreatedAt: product.Brand.CreatedAt.Format(time.RFC3339),
-			UpdatedAt: product.Brand.UpdatedAt.Format(time.RFC3339),
-		}
+		response.Brand = product.Brand.ToResponseDTO()
+	}
+
+	for _, variant := range product.Variants {
+		response.Variants = append(response.Variants, *variant.ToResponseDTO())
+	}
+
+	for _, category := range product.Categories {
+		response.Categories = append(response.Categories, *category.ToResponseDTO())
+	}
+
+	for _, tag := range product.Tags {
+		response.Tags = append(response.Tags, tag.TagName)
+	}
+
+	for _, ingredient := range product.Ingredients {
+		response.Ingredients = append(response.Ingredients, *ingredient.ToResponseDTO())
+	}
+
+	for _, image := range product.Images {
+		response.Images = append(response.Images, *image.ToResponseDTO())
+	}
+
+	for _, component := range product.Components {
+		response.Components = append(response.Components, *component.ToResponseDTO())
 	}
 
 	return response
@@ -0,0 +1,542 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/pkg/slug"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"strings"
+)
+
+// bulkChecks holds what the items of a bulk request are checked against, loaded with one query
+// per kind instead of one per item
+type bulkChecks struct {
+	brands map[uuid.UUID]bool
+	// categoriesExist is set when every category the items mention exists
+	categoriesExist bool
+	takenNames      map[string]bool
+	claimedNames    map[string]bool
+	// slugs records the availability of slugs already known, including the ones earlier items of
+	// the request claimed
+	slugs map[string]bool
+}
+
+func (s *productService) BulkCreate(ctx context.Context, req entity.BulkCreateProductsRequest, rejected map[int][]string) (*entity.BulkResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.BulkCreate")
+	defer span.End()
+
+	if len(req.Items) > entity.MaxBulkItems {
+		return nil, entity.ErrTooManyBulkItems
+	}
+	response := entity.NewBulkResponse(len(req.Items), req.Atomic, rejected)
+
+	var (
+		brandIDs    []uuid.UUID
+		names       []string
+		categoryIDs []uuid.UUID
+		slugs       []string
+	)
+	for i, item := range req.Items {
+		if !response.Pending(i) {
+			continue
+		}
+		brandIDs = append(brandIDs, item.BrandID)
+		names = append(names, item.ProductName)
+		categoryIDs = append(categoryIDs, item.CategoryIDs...)
+		if item.Slug != "" {
+			slugs = append(slugs, item.Slug)
+		} else {
+			slugs = append(slugs, slug.Make(item.ProductName))
+		}
+	}
+
+	checks, err := s.loadBulkChecks(ctx, brandIDs, names, categoryIDs, req.PlannedBrandIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// New products own no slug yet, so the slugs their names give can be checked up front
+	taken, err := s.repo.TakenSlugs(ctx, slugs)
+	if err != nil {
+		s.logger.Error("failed to check product slugs", zap.Error(err))
+		return nil, err
+	}
+	for _, candidate := range slugs {
+		checks.slugs[candidate] = false
+	}
+	for _, candidate := range taken {
+		checks.slugs[candidate] = true
+	}
+
+	products := make([]*entity.Product, len(req.Items))
+	for i, item := range req.Items {
+		if !response.Pending(i) {
+			continue
+		}
+
+		item.CategoryIDs = uniqueIDs(item.CategoryIDs)
+		product := item.ToProductEntity()
+		err := s.checkBulkItem(ctx, checks, item.BrandID, item.ProductName, item.CategoryIDs)
+		if err == nil {
+			err = s.assignSlugWith(ctx, product, item.Slug, checks.slugTaken(func(ctx context.Context, slug string) (bool, error) {
+				return s.repo.IsSlugTaken(ctx, slug, uuid.Nil)
+			}))
+		}
+		if err != nil {
+			response.Fail(i, bulkStatusOf(err), err)
+			continue
+		}
+		checks.slugs[product.Slug] = true
+		products[i] = product
+	}
+
+	if response.Atomic && response.HasFailures() {
+		return abandonBulk(response), nil
+	}
+	if req.DryRun {
+		return validateBulk(response), nil
+	}
+
+	if err := s.resolveBulkTags(ctx, products, func(i int) []string { return req.Items[i].Tags }); err != nil {
+		return nil, err
+	}
+
+	indexes, pending := pendingProducts(response, products)
+	written := s.commitBatch(ctx, response, indexes, pending, entity.BulkStatusCreated, s.repo.CreateBatch)
+	if response.Atomic && response.HasFailures() {
+		return abandonBulk(response), nil
+	}
+
+	for _, product := range written {
+		s.audit.Record(ctx, entity.AuditActionCreate, entity.AuditEntityProduct, product.ID, nil, product.AuditSnapshot())
+	}
+
+	response.Tally()
+	return response, nil
+}
+
+func (s *productService) BulkUpdate(ctx context.Context, req entity.BulkUpdateProductsRequest, rejected map[int][]string) (*entity.BulkResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.BulkUpdate")
+	defer span.End()
+
+	if len(req.Items) > entity.MaxBulkItems {
+		return nil, entity.ErrTooManyBulkItems
+	}
+	response := entity.NewBulkResponse(len(req.Items), req.Atomic, rejected)
+
+	ids := make([]uuid.UUID, len(req.Items))
+	versions := make([]int, len(req.Items))
+	for i, item := range req.Items {
+		ids[i], versions[i] = item.ID, item.Version
+	}
+	loaded, err := s.loadBulkProducts(ctx, response, ids, versions)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		brandIDs    []uuid.UUID
+		names       []string
+		categoryIDs []uuid.UUID
+	)
+	for i, item := range req.Items {
+		if !response.Pending(i) {
+			continue
+		}
+		if item.BrandID != loaded[i].BrandID {
+			brandIDs = append(brandIDs, item.BrandID)
+		}
+		if item.ProductName != loaded[i].ProductName {
+			names = append(names, item.ProductName)
+		}
+		categoryIDs = append(categoryIDs, item.CategoryIDs...)
+	}
+
+	checks, err := s.loadBulkChecks(ctx, brandIDs, names, categoryIDs, req.PlannedBrandIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	befores := make([]entity.AuditSnapshot, len(req.Items))
+	for i, item := range req.Items {
+		if !response.Pending(i) {
+			continue
+		}
+
+		product := loaded[i]
+		brandID, name := item.BrandID, item.ProductName
+		if brandID == product.BrandID {
+			brandID = uuid.Nil
+		}
+		if name == product.ProductName {
+			name = ""
+		}
+		item.CategoryIDs = uniqueIDs(item.CategoryIDs)
+		err := s.checkBulkItem(ctx, checks, brandID, name, item.CategoryIDs)
+		if err == nil && len(product.Variants) > 0 && item.Price.Currency != product.Price.Currency {
+			err = entity.ErrCurrencyMismatch
+		}
+		if err != nil {
+			response.Fail(i, bulkStatusOf(err), err)
+			loaded[i] = nil
+			continue
+		}
+
+		befores[i] = product.AuditSnapshot()
+		renamed := product.ProductName != item.ProductName
+		product.UpdateFromRequest(item.UpdateProductRequest)
+
+		// A rename moves the product to a slug of its new name unless one is given
+		if item.Slug != "" || renamed {
+			if err := s.assignSlugWith(ctx, product, item.Slug, checks.slugTaken(func(ctx context.Context, slug string) (bool, error) {
+				return s.repo.IsSlugTaken(ctx, slug, product.ID)
+			})); err != nil {
+				response.Fail(i, bulkStatusOf(err), err)
+				loaded[i] = nil
+				continue
+			}
+			checks.slugs[product.Slug] = true
+		}
+
+		// A nil category list keeps the current assignment, an empty one clears it
+		if item.CategoryIDs != nil {
+			product.Categories = make([]entity.Category, len(item.CategoryIDs))
+			for n, id := range item.CategoryIDs {
+				product.Categories[n] = entity.Category{ID: id}
+			}
+		}
+	}
+
+	if response.Atomic && response.HasFailures() {
+		return abandonBulk(response), nil
+	}
+	if req.DryRun {
+		return validateBulk(response), nil
+	}
+
+	// Tags follow the same rule, nil keeps them and an empty list clears them
+	if err := s.resolveBulkTags(ctx, loaded, func(i int) []string { return req.Items[i].Tags }); err != nil {
+		return nil, err
+	}
+
+	indexes, pending := pendingProducts(response, loaded)
+	s.commitBatch(ctx, response, indexes, pending, entity.BulkStatusUpdated, s.repo.UpdateBatch)
+	if response.Atomic && response.HasFailures() {
+		return abandonBulk(response), nil
+	}
+
+	for i, product := range loaded {
+		if product != nil && response.Results[i].Status == entity.BulkStatusUpdated {
+			s.audit.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityProduct, product.ID, befores[i], product.AuditSnapshot())
+		}
+	}
+
+	response.Tally()
+	return response, nil
+}
+
+func (s *productService) BulkDelete(ctx context.Context, req entity.BulkDeleteProductsRequest, rejected map[int][]string) (*entity.BulkResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.product.BulkDelete")
+	defer span.End()
+
+	if len(req.Items) > entity.MaxBulkItems {
+		return nil, entity.ErrTooManyBulkItems
+	}
+	response := entity.NewBulkResponse(len(req.Items), req.Atomic, rejected)
+
+	ids := make([]uuid.UUID, len(req.Items))
+	versions := make([]int, len(req.Items))
+	for i, item := range req.Items {
+		ids[i], versions[i] = item.ID, item.Version
+	}
+	loaded, err := s.loadBulkProducts(ctx, response, ids, versions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bundles would lose part of their contents
+	components, err := s.repo.FilterBundleComponents(ctx, ids)
+	if err != nil {
+		s.logger.Error("failed to check bundle membership", zap.Error(err))
+		return nil, err
+	}
+	inBundle := make(map[uuid.UUID]bool, len(components))
+	for _, id := range components {
+		inBundle[id] = true
+	}
+	for i, product := range loaded {
+		if product != nil && inBundle[product.ID] {
+			response.Fail(i, entity.BulkStatusConflict, entity.ErrProductInBundle)
+			loaded[i] = nil
+		}
+	}
+
+	if response.Atomic && response.HasFailures() {
+		return abandonBulk(response), nil
+	}
+
+	indexes, pending := pendingProducts(response, loaded)
+	written := s.commitBatch(ctx, response, indexes, pending, entity.BulkStatusDeleted, s.repo.DeleteBatch)
+	if response.Atomic && response.HasFailures() {
+		return abandonBulk(response), nil
+	}
+
+	for _, product := range written {
+		s.audit.Record(ctx, entity.AuditActionDelete, entity.AuditEntityProduct, product.ID, product.AuditSnapshot(), nil)
+	}
+
+	response.Tally()
+	return response, nil
+}
+
+// loadBulkChecks looks up what the items refer to, the planned brands are taken as existing
+func (s *productService) loadBulkChecks(ctx context.Context, brandIDs []uuid.UUID, names []string, categoryIDs []uuid.UUID, plannedBrandIDs []uuid.UUID) (*bulkChecks, error) {
+	checks := &bulkChecks{
+		brands:       map[uuid.UUID]bool{},
+		takenNames:   map[string]bool{},
+		claimedNames: map[string]bool{},
+		slugs:        map[string]bool{},
+	}
+
+	for _, id := range plannedBrandIDs {
+		checks.brands[id] = true
+	}
+
+	// A bulk request usually lists the products of a handful of brands
+	for _, id := range uniqueIDs(brandIDs) {
+		if checks.brands[id] {
+			continue
+		}
+		exists, err := s.brandRepo.ExistsByID(ctx, id)
+		if err != nil {
+			s.logger.Error("failed to check brand existence", zap.Error(err))
+			return nil, err
+		}
+		checks.brands[id] = exists
+	}
+
+	taken, err := s.repo.ExistingNames(ctx, names)
+	if err != nil {
+		s.logger.Error("failed to check product existence", zap.Error(err))
+		return nil, err
+	}
+	for _, name := range taken {
+		checks.takenNames[name] = true
+	}
+
+	categoryIDs = uniqueIDs(categoryIDs)
+	checks.categoriesExist = len(categoryIDs) == 0
+	if !checks.categoriesExist {
+		count, err := s.categoryRepo.CountByIDs(ctx, categoryIDs)
+		if err != nil {
+			s.logger.Error("failed to check category existence", zap.Error(err))
+			return nil, err
+		}
+		checks.categoriesExist = count == int64(len(categoryIDs))
+	}
+
+	return checks, nil
+}
+
+// checkBulkItem checks the brand, name and categories an item sets, a nil brand ID or an empty
+// name is one the item keeps and is not checked
+func (s *productService) checkBulkItem(ctx context.Context, checks *bulkChecks, brandID uuid.UUID, name string, categoryIDs []uuid.UUID) error {
+	if brandID != uuid.Nil && !checks.brands[brandID] {
+		return fmt.Errorf("brand with ID %s not found", brandID)
+	}
+
+	if name != "" {
+		if checks.takenNames[name] {
+			return fmt.Errorf("%w: %s", entity.ErrProductNameTaken, name)
+		}
+		if checks.claimedNames[name] {
+			return fmt.Errorf("%w: product name %s", entity.ErrDuplicateBulkItem, name)
+		}
+	}
+
+	// Only a request mentioning a missing category pays for a query per item to find which
+	if !checks.categoriesExist {
+		if err := s.ensureCategoriesExist(ctx, categoryIDs); err != nil {
+			return err
+		}
+	}
+
+	if name != "" {
+		checks.claimedNames[name] = true
+	}
+	return nil
+}
+
+// slugTaken reports the slugs the request already knows about and asks fallback for the others
+func (c *bulkChecks) slugTaken(fallback func(ctx context.Context, slug string) (bool, error)) func(ctx context.Context, slug string) (bool, error) {
+	return func(ctx context.Context, slug string) (bool, error) {
+		if taken, ok := c.slugs[slug]; ok {
+			return taken, nil
+		}
+		return fallback(ctx, slug)
+	}
+}
+
+// loadBulkProducts loads the products the pending items address in one query and fails the items
+// that repeat a product, address a missing one or carry a stale version. Products are returned
+// at the position of their item.
+func (s *productService) loadBulkProducts(ctx context.Context, response *entity.BulkResponse, ids []uuid.UUID, versions []int) ([]*entity.Product, error) {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	wanted := make([]uuid.UUID, 0, len(ids))
+	for i, id := range ids {
+		if !response.Pending(i) {
+			continue
+		}
+		if seen[id] {
+			response.Fail(i, entity.BulkStatusConflict, fmt.Errorf("%w: product %s", entity.ErrDuplicateBulkItem, id))
+			continue
+		}
+		seen[id] = true
+		wanted = append(wanted, id)
+	}
+
+	products, err := s.repo.GetByIDs(ctx, wanted)
+	if err != nil {
+		s.logger.Error("failed to get products", zap.Error(err))
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*entity.Product, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+
+	loaded := make([]*entity.Product, len(ids))
+	for i, id := range ids {
+		if !response.Pending(i) {
+			continue
+		}
+		product, ok := byID[id]
+		if !ok {
+			response.Fail(i, entity.BulkStatusNotFound, fmt.Errorf("product not found"))
+			continue
+		}
+		if product.Version != versions[i] {
+			response.Fail(i, entity.BulkStatusVersionMismatch, fmt.Errorf("%w: product is at version %d", entity.ErrVersionMismatch, product.Version))
+			continue
+		}
+		loaded[i] = product
+	}
+
+	return loaded, nil
+}
+
+// resolveBulkTags finds or creates the tags of every pending product at once. Products whose
+// item lists no tags keep the ones they have.
+func (s *productService) resolveBulkTags(ctx context.Context, products []*entity.Product, tagsOf func(i int) []string) error {
+	var names []string
+	for i, product := range products {
+		if product != nil {
+			names = append(names, tagsOf(i)...)
+		}
+	}
+
+	tags, err := s.tagRepo.FindOrCreateByNames(ctx, entity.NormalizeTagNames(names))
+	if err != nil {
+		s.logger.Error("failed to resolve product tags", zap.Error(err))
+		return err
+	}
+	byName := make(map[string]entity.Tag, len(tags))
+	for _, tag := range tags {
+		byName[tag.TagName] = tag
+	}
+
+	for i, product := range products {
+		if product == nil || tagsOf(i) == nil {
+			continue
+		}
+		product.Tags = []entity.Tag{}
+		for _, name := range entity.NormalizeTagNames(tagsOf(i)) {
+			product.Tags = append(product.Tags, byName[name])
+		}
+	}
+	return nil
+}
+
+// commitBatch writes the products of the items at indexes in one transaction and returns the
+// written ones. Outside atomic mode an item the write fails on is dropped and the rest are
+// written again, a failure not tied to an item fails them all.
+func (s *productService) commitBatch(ctx context.Context, response *entity.BulkResponse, indexes []int, products []*entity.Product, status string, write func(ctx context.Context, products []*entity.Product) error) []*entity.Product {
+	for len(products) > 0 {
+		err := write(ctx, products)
+		if err == nil {
+			for i, product := range products {
+				response.Succeed(indexes[i], status, product.ID)
+			}
+			return products
+		}
+		s.logger.Error("failed to write product batch", zap.Error(err))
+
+		var itemErr *entity.BatchItemError
+		if !errors.As(err, &itemErr) {
+			for _, index := range indexes {
+				response.Fail(index, entity.BulkStatusFailed, err)
+			}
+			return nil
+		}
+
+		response.Fail(indexes[itemErr.Index], bulkStatusOf(itemErr.Err), itemErr.Err)
+		if response.Atomic {
+			return nil
+		}
+		indexes = append(indexes[:itemErr.Index], indexes[itemErr.Index+1:]...)
+		products = append(products[:itemErr.Index], products[itemErr.Index+1:]...)
+	}
+	return nil
+}
+
+// pendingProducts lists the products of the items still waiting for an outcome with their index
+func pendingProducts(response *entity.BulkResponse, products []*entity.Product) ([]int, []*entity.Product) {
+	var (
+		indexes []int
+		pending []*entity.Product
+	)
+	for i, product := range products {
+		if product != nil && response.Pending(i) {
+			indexes = append(indexes, i)
+			pending = append(pending, product)
+		}
+	}
+	return indexes, pending
+}
+
+// abandonBulk settles an atomic request one of whose items failed, nothing was written
+func abandonBulk(response *entity.BulkResponse) *entity.BulkResponse {
+	response.Abandon()
+	response.Tally()
+	return response
+}
+
+// validateBulk settles a dry run, every item that passed the checks is reported as valid
+func validateBulk(response *entity.BulkResponse) *entity.BulkResponse {
+	response.Validate()
+	response.Tally()
+	return response
+}
+
+// bulkStatusOf maps the error an item failed with to its bulk result status
+func bulkStatusOf(err error) string {
+	switch {
+	case errors.Is(err, entity.ErrVersionMismatch):
+		return entity.BulkStatusVersionMismatch
+	case errors.Is(err, entity.ErrProductNameTaken),
+		errors.Is(err, entity.ErrProductSlugTaken),
+		errors.Is(err, entity.ErrDuplicateBulkItem),
+		errors.Is(err, entity.ErrProductInBundle),
+		errors.Is(err, entity.ErrInsufficientStock):
+		return entity.BulkStatusConflict
+	case errors.Is(err, entity.ErrCurrencyMismatch):
+		return entity.BulkStatusInvalid
+	case strings.HasSuffix(err.Error(), "not found"):
+		return entity.BulkStatusNotFound
+	default:
+		return entity.BulkStatusFailed
+	}
+}
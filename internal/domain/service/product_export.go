@@ -0,0 +1,103 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+)
+
+// exportFlushRows is how many rows are buffered before they are pushed to the writer
+const exportFlushRows = 100
+
+type productExportService struct {
+	repo   entity.ProductRepository
+	logger *zap.Logger
+	tracer *tracing.Tracer
+}
+
+func NewProductExportService(
+	repo entity.ProductRepository,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) entity.ProductExportService {
+	return &productExportService{
+		repo:   repo,
+		logger: logger,
+		tracer: tracer,
+	}
+}
+
+// Export streams the products matching the filter to w as they are read from the database, only a
+// batch of rows is held in memory at a time. Pagination of the filter is ignored.
+func (s *productExportService) Export(ctx context.Context, filter entity.ProductFilterRequest, format string, w io.Writer) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productExport.Export")
+	defer span.End()
+
+	var (
+		write func(row *entity.ProductExportRow) error
+		flush func() error
+	)
+	switch format {
+	case entity.ExportFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(entity.ProductExportColumns); err != nil {
+			return 0, fmt.Errorf("failed to write export header: %w", err)
+		}
+		write = func(row *entity.ProductExportRow) error {
+			return writer.Write(row.CSVRecord())
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	case entity.ExportFormatNDJSON:
+		buffered := bufio.NewWriter(w)
+		encoder := json.NewEncoder(buffered)
+		write = func(row *entity.ProductExportRow) error {
+			return encoder.Encode(row)
+		}
+		flush = buffered.Flush
+	default:
+		return 0, fmt.Errorf("%w, got %q", entity.ErrInvalidExportFormat, format)
+	}
+
+	count := 0
+	err := s.repo.StreamWithFilter(ctx, filter.ToProductFilterRepo(), func(row *entity.ProductExportRow) error {
+		if err := write(row); err != nil {
+			return fmt.Errorf("failed to write exported product: %w", err)
+		}
+		count++
+		if count%exportFlushRows == 0 {
+			return flushExport(w, flush)
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to export products", zap.Int("exported", count), zap.Error(err))
+		return count, err
+	}
+
+	if err := flushExport(w, flush); err != nil {
+		s.logger.Error("failed to export products", zap.Int("exported", count), zap.Error(err))
+		return count, err
+	}
+
+	return count, nil
+}
+
+// flushExport pushes the buffered rows to w, and on to the client when w is a response
+func flushExport(w io.Writer, flush func() error) error {
+	if err := flush(); err != nil {
+		return fmt.Errorf("failed to write exported products: %w", err)
+	}
+	if flusher, ok := w.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/media"
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+)
+
+type ProductImageOptions struct {
+	MaxUploadSize int64
+}
+
+type productImageService struct {
+	repo        entity.ProductImageRepository
+	productRepo entity.ProductRepository
+	storage     media.MediaStorage
+	opts        ProductImageOptions
+	logger      *zap.Logger
+	tracer      *tracing.Tracer
+}
+
+func NewProductImageService(
+	repo entity.ProductImageRepository,
+	productRepo entity.ProductRepository,
+	storage media.MediaStorage,
+	opts ProductImageOptions,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) entity.ProductImageService {
+	return &productImageService{
+		repo:        repo,
+		productRepo: productRepo,
+		storage:     storage,
+		opts:        opts,
+		logger:      logger,
+		tracer:      tracer,
+	}
+}
+
+func (s *productImageService) Upload(ctx context.Context, productID uuid.UUID, req entity.UploadProductImageRequest) (*entity.ProductImageResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productImage.Upload")
+	defer span.End()
+
+	if err := s.ensureProductExists(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	if s.opts.MaxUploadSize > 0 && req.Size > s.opts.MaxUploadSize {
+		return nil, entity.ErrImageTooLarge
+	}
+
+	// Trust the file content rather than the client supplied content type or extension
+	head := make([]byte, 512)
+	n, err := io.ReadFull(req.Body, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		s.logger.Error("failed to read uploaded image", zap.Error(err))
+		return nil, fmt.Errorf("failed to read uploaded image: %w", err)
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	ext, ok := entity.AllowedImageTypes[contentType]
+	if !ok {
+		return nil, entity.ErrUnsupportedImageType
+	}
+
+	image := &entity.ProductImage{
+		ID:          uuid.New(),
+		ProductID:   productID,
+		ContentType: contentType,
+		Size:        req.Size,
+		IsPrimary:   req.IsPrimary,
+	}
+	image.StorageKey = fmt.Sprintf("products/%s/%s%s", productID, image.ID, ext)
+	image.URL = s.storage.URL(image.StorageKey)
+
+	body := io.MultiReader(bytes.NewReader(head), req.Body)
+	if err := s.storage.Put(ctx, image.StorageKey, body, req.Size, contentType); err != nil {
+		s.logger.Error("failed to store product image", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, image); err != nil {
+		s.logger.Error("failed to create product image", zap.Error(err))
+		s.removeStoredFile(ctx, image.StorageKey)
+		return nil, err
+	}
+
+	return image.ToResponseDTO(), nil
+}
+
+func (s *productImageService) GetAll(ctx context.Context, productID uuid.UUID) ([]entity.ProductImageResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productImage.GetAll")
+	defer span.End()
+
+	if err := s.ensureProductExists(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, productID)
+}
+
+func (s *productImageService) Reorder(ctx context.Context, productID uuid.UUID, req entity.ReorderProductImagesRequest) ([]entity.ProductImageResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productImage.Reorder")
+	defer span.End()
+
+	if err := s.repo.Reorder(ctx, productID, req.ImageIDs); err != nil {
+		s.logger.Error("failed to reorder product images", zap.Error(err))
+		return nil, err
+	}
+
+	return s.list(ctx, productID)
+}
+
+func (s *productImageService) SetPrimary(ctx context.Context, productID, id uuid.UUID) (*entity.ProductImageResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productImage.SetPrimary")
+	defer span.End()
+
+	if err := s.repo.SetPrimary(ctx, productID, id); err != nil {
+		s.logger.Error("failed to set primary product image", zap.Error(err))
+		return nil, err
+	}
+
+	image, err := s.repo.GetByID(ctx, productID, id)
+	if err != nil {
+		s.logger.Error("failed to get product image", zap.Error(err))
+		return nil, err
+	}
+	if image == nil {
+		return nil, entity.ErrProductImageNotFound
+	}
+
+	return image.ToResponseDTO(), nil
+}
+
+func (s *productImageService) Delete(ctx context.Context, productID, id uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "service.productImage.Delete")
+	defer span.End()
+
+	image, err := s.repo.GetByID(ctx, productID, id)
+	if err != nil {
+		s.logger.Error("failed to get product image", zap.Error(err))
+		return err
+	}
+	if image == nil {
+		return entity.ErrProductImageNotFound
+	}
+
+	if err := s.repo.Delete(ctx, productID, id); err != nil {
+		s.logger.Error("failed to delete product image", zap.Error(err))
+		return err
+	}
+
+	// The row is gone, so a leftover file is only wasted space and not worth failing the request for
+	s.removeStoredFile(ctx, image.StorageKey)
+
+	return nil
+}
+
+func (s *productImageService) list(ctx context.Context, productID uuid.UUID) ([]entity.ProductImageResponse, error) {
+	images, err := s.repo.GetAllByProductID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get product images", zap.Error(err))
+		return nil, err
+	}
+
+	responses := make([]entity.ProductImageResponse, len(images))
+	for i, image := range images {
+		responses[i] = *image.ToResponseDTO()
+	}
+
+	return responses, nil
+}
+
+func (s *productImageService) removeStoredFile(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		s.logger.Error("failed to remove stored product image", zap.String("key", key), zap.Error(err))
+	}
+}
+
+func (s *productImageService) ensureProductExists(ctx context.Context, productID uuid.UUID) error {
+	exists, err := s.productRepo.ExistsByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to check product existence", zap.Error(err))
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("product not found")
+	}
+	return nil
+}
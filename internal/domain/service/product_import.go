@@ -0,0 +1,388 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/money"
+	"Unnispick/pkg/validator"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// importColumns are the columns an import file may have, the required ones must be present
+var importColumns = map[string]bool{
+	entity.ImportColumnProductName: true,
+	entity.ImportColumnSlug:        false,
+	entity.ImportColumnPrice:       true,
+	entity.ImportColumnCurrency:    false,
+	entity.ImportColumnQuantity:    true,
+	entity.ImportColumnBrandName:   true,
+	entity.ImportColumnCategoryIDs: false,
+	entity.ImportColumnTags:        false,
+}
+
+// importValidatedFields are the request fields checked before brands are resolved
+var importValidatedFields = []string{"product_name", "slug", "price", "quantity", "category_ids", "tags"}
+
+type productImportService struct {
+	products     entity.ProductService
+	repo         entity.ProductRepository
+	brandRepo    entity.BrandRepository
+	brandService entity.BrandService
+	validate     *validator.Validator
+	logger       *zap.Logger
+	tracer       *tracing.Tracer
+}
+
+// importRow is a data row of an import file mapped to a product request, errors holds what is
+// wrong with it
+type importRow struct {
+	line      int
+	brandName string
+	req       entity.CreateProductRequest
+	errors    []string
+}
+
+func NewProductImportService(
+	products entity.ProductService,
+	repo entity.ProductRepository,
+	brandRepo entity.BrandRepository,
+	brandService entity.BrandService,
+	validate *validator.Validator,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) entity.ProductImportService {
+	return &productImportService{
+		products:     products,
+		repo:         repo,
+		brandRepo:    brandRepo,
+		brandService: brandService,
+		validate:     validate,
+		logger:       logger,
+		tracer:       tracer,
+	}
+}
+
+// Import creates the products of a CSV file and updates the ones whose name already exists. Rows
+// are written in bulk batches, a row that fails does not keep the others from being written.
+func (s *productImportService) Import(ctx context.Context, file io.Reader, opts entity.ProductImportOptions) (*entity.ProductImportReport, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productImport.Import")
+	defer span.End()
+
+	rows, err := readImportRows(file)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.ProductImportReport{
+		DryRun: opts.DryRun,
+		Rows:   make([]entity.ProductImportRowResult, len(rows)),
+	}
+
+	// The brand is checked once it is resolved, so a row that is wrong anyway creates no brand
+	for i := range rows {
+		if len(rows[i].errors) > 0 {
+			continue
+		}
+		if err := s.validate.ValidateFields(ctx, rows[i].req, importValidatedFields...); err != nil {
+			for _, ve := range s.validate.ExtractValidationErrors(err) {
+				rows[i].errors = append(rows[i].errors, fmt.Sprintf("%s: %s", ve.Field, ve.Message))
+			}
+		}
+	}
+
+	planned, err := s.resolveBrands(ctx, rows, opts, report)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, row := range rows {
+		if len(row.errors) == 0 {
+			names = append(names, row.req.ProductName)
+		}
+	}
+
+	// Rows are matched to existing products by name
+	existing, err := s.repo.GetVersionsByNames(ctx, names)
+	if err != nil {
+		s.logger.Error("failed to get products by name", zap.Error(err))
+		return nil, err
+	}
+	byName := make(map[string]entity.Product, len(existing))
+	for _, product := range existing {
+		byName[product.ProductName] = product
+	}
+
+	var creates, updates []int
+	for i, row := range rows {
+		report.Rows[i].Row = row.line
+		report.Rows[i].ProductName = row.req.ProductName
+		if len(row.errors) > 0 {
+			report.Rows[i].Status = entity.BulkStatusInvalid
+			report.Rows[i].Errors = row.errors
+			continue
+		}
+		if _, ok := byName[row.req.ProductName]; ok {
+			report.Rows[i].Action = entity.ImportActionUpdate
+			updates = append(updates, i)
+		} else {
+			report.Rows[i].Action = entity.ImportActionCreate
+			creates = append(creates, i)
+		}
+	}
+
+	for _, chunk := range chunkRows(creates) {
+		req := entity.BulkCreateProductsRequest{DryRun: opts.DryRun, PlannedBrandIDs: planned}
+		for _, i := range chunk {
+			req.Items = append(req.Items, rows[i].req)
+		}
+
+		response, err := s.products.BulkCreate(ctx, req, nil)
+		if err != nil {
+			return nil, err
+		}
+		copyBulkResults(report, chunk, response)
+	}
+
+	for _, chunk := range chunkRows(updates) {
+		req := entity.BulkUpdateProductsRequest{DryRun: opts.DryRun, PlannedBrandIDs: planned}
+		for _, i := range chunk {
+			current := byName[rows[i].req.ProductName]
+			req.Items = append(req.Items, entity.BulkUpdateProductRequest{
+				ID:      current.ID,
+				Version: current.Version,
+				UpdateProductRequest: entity.UpdateProductRequest{
+					ProductName: rows[i].req.ProductName,
+					Slug:        rows[i].req.Slug,
+					Price:       rows[i].req.Price,
+					Quantity:    rows[i].req.Quantity,
+					BrandID:     rows[i].req.BrandID,
+					CategoryIDs: rows[i].req.CategoryIDs,
+					Tags:        rows[i].req.Tags,
+				},
+			})
+		}
+
+		response, err := s.products.BulkUpdate(ctx, req, nil)
+		if err != nil {
+			return nil, err
+		}
+		copyBulkResults(report, chunk, response)
+	}
+
+	report.Tally()
+	return report, nil
+}
+
+// resolveBrands sets the brand ID of every row from its brand name. Missing brands are created
+// when asked to, a dry run only plans them under a made up ID that is returned.
+func (s *productImportService) resolveBrands(ctx context.Context, rows []importRow, opts entity.ProductImportOptions, report *entity.ProductImportReport) ([]uuid.UUID, error) {
+	var planned []uuid.UUID
+	brandIDs := map[string]uuid.UUID{}
+	brandErrors := map[string]string{}
+
+	for i := range rows {
+		name := rows[i].brandName
+		if len(rows[i].errors) > 0 {
+			continue
+		}
+		if _, ok := brandIDs[name]; ok {
+			rows[i].req.BrandID = brandIDs[name]
+			continue
+		}
+		if message, ok := brandErrors[name]; ok {
+			rows[i].errors = append(rows[i].errors, message)
+			continue
+		}
+
+		brand, err := s.brandRepo.GetByName(ctx, name)
+		if err != nil {
+			s.logger.Error("failed to get brand by name", zap.Error(err))
+			return nil, err
+		}
+
+		switch {
+		case brand != nil:
+			brandIDs[name] = brand.ID
+		case !opts.CreateBrands:
+			brandErrors[name] = fmt.Sprintf("%s: brand %s not found", entity.ImportColumnBrandName, name)
+		case opts.DryRun:
+			brandIDs[name] = uuid.New()
+			planned = append(planned, brandIDs[name])
+			report.CreatedBrands = append(report.CreatedBrands, name)
+		default:
+			created, err := s.brandService.Create(ctx, entity.CreateBrandRequest{BrandName: name})
+			if err != nil {
+				brandErrors[name] = fmt.Sprintf("%s: failed to create brand %s: %v", entity.ImportColumnBrandName, name, err)
+				break
+			}
+			brandIDs[name] = created.ID
+			report.CreatedBrands = append(report.CreatedBrands, name)
+		}
+
+		if message, ok := brandErrors[name]; ok {
+			rows[i].errors = append(rows[i].errors, message)
+			continue
+		}
+		rows[i].req.BrandID = brandIDs[name]
+	}
+
+	return planned, nil
+}
+
+// readImportRows reads the header and the data rows of an import file. A cell that cannot be
+// read into its field is reported on its row, a file that is not a usable CSV fails as a whole.
+func readImportRows(file io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", entity.ErrInvalidImportFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", entity.ErrInvalidImportFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheet exports often start with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		// A file exported from the catalog carries columns the import has no use for
+		if entity.IsExportOnlyColumn(name) {
+			continue
+		}
+		if _, known := importColumns[name]; !known {
+			return nil, fmt.Errorf("%w: unknown column %q", entity.ErrInvalidImportFile, name)
+		}
+		if _, repeated := columns[name]; repeated {
+			return nil, fmt.Errorf("%w: column %q appears twice", entity.ErrInvalidImportFile, name)
+		}
+		columns[name] = i
+	}
+	for name, required := range importColumns {
+		if _, ok := columns[name]; required && !ok {
+			return nil, fmt.Errorf("%w: missing column %q", entity.ErrInvalidImportFile, name)
+		}
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", entity.ErrInvalidImportFile, err)
+		}
+		if len(rows) == entity.MaxImportRows {
+			return nil, fmt.Errorf("%w: more than %d rows", entity.ErrInvalidImportFile, entity.MaxImportRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := parseImportRow(columns, record)
+		row.line = line
+		if len(record) != len(header) {
+			row.errors = append([]string{fmt.Sprintf("row has %d cells, the header has %d", len(record), len(header))}, row.errors...)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseImportRow(columns map[string]int, record []string) importRow {
+	cell := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	row := importRow{
+		brandName: cell(entity.ImportColumnBrandName),
+		req: entity.CreateProductRequest{
+			ProductName: cell(entity.ImportColumnProductName),
+			Slug:        cell(entity.ImportColumnSlug),
+			Tags:        splitImportList(cell(entity.ImportColumnTags)),
+		},
+	}
+
+	if value := cell(entity.ImportColumnPrice); value != "" {
+		price, err := money.Parse(value, cell(entity.ImportColumnCurrency))
+		if err != nil {
+			row.errors = append(row.errors, fmt.Sprintf("%s: %v", entity.ImportColumnPrice, err))
+		}
+		row.req.Price = price
+	}
+
+	if value := cell(entity.ImportColumnQuantity); value != "" {
+		quantity, err := strconv.Atoi(value)
+		if err != nil {
+			row.errors = append(row.errors, fmt.Sprintf("%s: %q is not a whole number", entity.ImportColumnQuantity, value))
+		}
+		row.req.Quantity = quantity
+	}
+
+	for _, value := range splitImportList(cell(entity.ImportColumnCategoryIDs)) {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			row.errors = append(row.errors, fmt.Sprintf("%s: %q is not a UUID", entity.ImportColumnCategoryIDs, value))
+			continue
+		}
+		row.req.CategoryIDs = append(row.req.CategoryIDs, id)
+	}
+
+	if row.brandName == "" {
+		row.errors = append(row.errors, fmt.Sprintf("%s: a brand name is required", entity.ImportColumnBrandName))
+	}
+
+	return row
+}
+
+// splitImportList splits a list cell, an empty cell gives nil so an existing product keeps what
+// it has
+func splitImportList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	var values []string
+	for _, part := range strings.Split(value, entity.ImportListSeparator) {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+// chunkRows splits row indexes into batches a bulk request accepts
+func chunkRows(indexes []int) [][]int {
+	var chunks [][]int
+	for len(indexes) > entity.MaxBulkItems {
+		chunks = append(chunks, indexes[:entity.MaxBulkItems])
+		indexes = indexes[entity.MaxBulkItems:]
+	}
+	if len(indexes) > 0 {
+		chunks = append(chunks, indexes)
+	}
+	return chunks
+}
+
+func copyBulkResults(report *entity.ProductImportReport, chunk []int, response *entity.BulkResponse) {
+	for n, result := range response.Results {
+		row := &report.Rows[chunk[n]]
+		row.Status = result.Status
+		row.ID = result.ID
+		row.Errors = result.Errors
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"Unnispick/pkg/actor"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"time"
+)
+
+type productPriceService struct {
+	repo        entity.ProductPriceRepository
+	productRepo entity.ProductRepository
+	audit       entity.AuditService
+	logger      *zap.Logger
+	tracer      *tracing.Tracer
+}
+
+func NewProductPriceService(
+	repo entity.ProductPriceRepository,
+	productRepo entity.ProductRepository,
+	audit entity.AuditService,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) entity.ProductPriceService {
+	return &productPriceService{
+		repo:        repo,
+		productRepo: productRepo,
+		audit:       audit,
+		logger:      logger,
+		tracer:      tracer,
+	}
+}
+
+func (s *productPriceService) GetHistory(ctx context.Context, productID uuid.UUID, filter entity.PriceHistoryFilterRequest) ([]entity.ProductPriceResponse, int64, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productPrice.GetHistory")
+	defer span.End()
+
+	if err := s.ensureProductExists(ctx, productID); err != nil {
+		return nil, 0, err
+	}
+
+	prices, count, err := s.repo.GetHistoryWithFilter(ctx, productID, filter.ToPriceHistoryFilterRepo())
+	if err != nil {
+		s.logger.Error("failed to get price history", zap.Error(err))
+		return nil, 0, err
+	}
+
+	responses := make([]entity.ProductPriceResponse, len(prices))
+	for i, price := range prices {
+		responses[i] = *price.ToResponseDTO()
+	}
+
+	return responses, count, nil
+}
+
+func (s *productPriceService) GetScheduled(ctx context.Context, productID uuid.UUID) ([]entity.ProductPriceResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productPrice.GetScheduled")
+	defer span.End()
+
+	if err := s.ensureProductExists(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	prices, err := s.repo.GetScheduled(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get scheduled prices", zap.Error(err))
+		return nil, err
+	}
+
+	responses := make([]entity.ProductPriceResponse, len(prices))
+	for i, price := range prices {
+		responses[i] = *price.ToResponseDTO()
+	}
+
+	return responses, nil
+}
+
+func (s *productPriceService) Schedule(ctx context.Context, productID uuid.UUID, req entity.SchedulePriceChangeRequest) (*entity.ProductPriceResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productPrice.Schedule")
+	defer span.End()
+
+	if !req.EffectiveFrom.After(time.Now()) {
+		return nil, entity.ErrPriceScheduleInPast
+	}
+
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get product", zap.Error(err))
+		return nil, err
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+
+	// Variants are priced in the product currency, so a scheduled change cannot switch it either
+	if len(product.Variants) > 0 && req.Price.Currency != product.Price.Currency {
+		return nil, entity.ErrCurrencyMismatch
+	}
+
+	price := &entity.ProductPrice{
+		ProductID:     productID,
+		Price:         req.Price,
+		EffectiveFrom: req.EffectiveFrom,
+	}
+	if err := s.repo.Schedule(ctx, price); err != nil {
+		s.logger.Error("failed to schedule price change", zap.Error(err))
+		return nil, err
+	}
+
+	return price.ToResponseDTO(), nil
+}
+
+func (s *productPriceService) CancelScheduled(ctx context.Context, productID, id uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "service.productPrice.CancelScheduled")
+	defer span.End()
+
+	if err := s.repo.CancelScheduled(ctx, productID, id); err != nil {
+		s.logger.Error("failed to cancel scheduled price change", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *productPriceService) ApplyDue(ctx context.Context) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productPrice.ApplyDue")
+	defer span.End()
+
+	// Changes that could be applied are kept even when others failed
+	applied, err := s.repo.ApplyDue(ctx, time.Now())
+	if len(applied) > 0 {
+		s.logger.Info("applied scheduled price changes", zap.Int("count", len(applied)))
+	}
+	// Each change is recorded against whoever scheduled it
+	for _, change := range applied {
+		s.audit.Record(actor.WithActor(ctx, change.Actor), entity.AuditActionApplyPrice, entity.AuditEntityProduct, change.ProductID,
+			entity.AuditSnapshot{"price": change.Previous},
+			entity.AuditSnapshot{"price": change.Price},
+		)
+	}
+	if err != nil {
+		s.logger.Error("failed to apply scheduled price changes", zap.Error(err))
+		return len(applied), err
+	}
+
+	return len(applied), nil
+}
+
+func (s *productPriceService) ensureProductExists(ctx context.Context, productID uuid.UUID) error {
+	exists, err := s.productRepo.ExistsByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to check product existence", zap.Error(err))
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("product not found")
+	}
+	return nil
+}
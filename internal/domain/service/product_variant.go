@@ -0,0 +1,173 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type productVariantService struct {
+	repo        entity.ProductVariantRepository
+	productRepo entity.ProductRepository
+	logger      *zap.Logger
+	tracer      *tracing.Tracer
+}
+
+func NewProductVariantService(
+	repo entity.ProductVariantRepository,
+	productRepo entity.ProductRepository,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) entity.ProductVariantService {
+	return &productVariantService{
+		repo:        repo,
+		productRepo: productRepo,
+		logger:      logger,
+		tracer:      tracer,
+	}
+}
+
+func (s *productVariantService) Create(ctx context.Context, productID uuid.UUID, req entity.CreateProductVariantRequest) (*entity.ProductVariantResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productVariant.Create")
+	defer span.End()
+
+	if err := s.ensureProductCurrency(ctx, productID, req.Price.Currency); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repo.ExistsBySKU(ctx, req.SKU)
+	if err != nil {
+		s.logger.Error("failed to check product variant sku existence", zap.Error(err))
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("product variant with sku %s already exists", req.SKU)
+	}
+
+	variant := req.ToProductVariantEntity(productID)
+	if err := s.repo.Create(ctx, variant); err != nil {
+		s.logger.Error("failed to create product variant", zap.Error(err))
+		return nil, err
+	}
+
+	return variant.ToResponseDTO(), nil
+}
+
+func (s *productVariantService) GetByID(ctx context.Context, productID, id uuid.UUID) (*entity.ProductVariantResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productVariant.GetByID")
+	defer span.End()
+
+	variant, err := s.repo.GetByID(ctx, productID, id)
+	if err != nil {
+		s.logger.Error("failed to get product variant", zap.Error(err))
+		return nil, err
+	}
+	if variant == nil {
+		return nil, entity.ErrProductVariantNotFound
+	}
+
+	return variant.ToResponseDTO(), nil
+}
+
+func (s *productVariantService) GetAll(ctx context.Context, productID uuid.UUID) ([]entity.ProductVariantResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productVariant.GetAll")
+	defer span.End()
+
+	if err := s.ensureProductExists(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	variants, err := s.repo.GetAllByProductID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get product variants", zap.Error(err))
+		return nil, err
+	}
+
+	responses := make([]entity.ProductVariantResponse, len(variants))
+	for i, variant := range variants {
+		responses[i] = *variant.ToResponseDTO()
+	}
+
+	return responses, nil
+}
+
+func (s *productVariantService) Update(ctx context.Context, productID, id uuid.UUID, req entity.UpdateProductVariantRequest) (*entity.ProductVariantResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.productVariant.Update")
+	defer span.End()
+
+	variant, err := s.repo.GetByID(ctx, productID, id)
+	if err != nil {
+		s.logger.Error("failed to get product variant", zap.Error(err))
+		return nil, err
+	}
+	if variant == nil {
+		return nil, entity.ErrProductVariantNotFound
+	}
+
+	if err := s.ensureProductCurrency(ctx, productID, req.Price.Currency); err != nil {
+		return nil, err
+	}
+
+	// Check if new sku conflicts with another variant
+	if req.SKU != variant.SKU {
+		exists, err := s.repo.ExistsBySKU(ctx, req.SKU)
+		if err != nil {
+			s.logger.Error("failed to check product variant sku existence", zap.Error(err))
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("product variant with sku %s already exists", req.SKU)
+		}
+	}
+
+	variant.UpdateFromRequest(req)
+	if err := s.repo.Update(ctx, variant); err != nil {
+		s.logger.Error("failed to update product variant", zap.Error(err))
+		return nil, err
+	}
+
+	return variant.ToResponseDTO(), nil
+}
+
+func (s *productVariantService) Delete(ctx context.Context, productID, id uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "service.productVariant.Delete")
+	defer span.End()
+
+	if err := s.repo.Delete(ctx, productID, id); err != nil {
+		s.logger.Error("failed to delete product variant", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *productVariantService) ensureProductExists(ctx context.Context, productID uuid.UUID) error {
+	exists, err := s.productRepo.ExistsByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to check product existence", zap.Error(err))
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("product not found")
+	}
+	return nil
+}
+
+// ensureProductCurrency checks the product exists and prices in the given currency
+func (s *productVariantService) ensureProductCurrency(ctx context.Context, productID uuid.UUID, currency string) error {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get product", zap.Error(err))
+		return err
+	}
+	if product == nil {
+		return fmt.Errorf("product not found")
+	}
+	if product.Price.Currency != currency {
+		return entity.ErrCurrencyMismatch
+	}
+	return nil
+}
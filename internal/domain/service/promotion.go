@@ -0,0 +1,158 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"context"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type promotionService struct {
+	repo         entity.PromotionRepository
+	productRepo  entity.ProductRepository
+	brandRepo    entity.BrandRepository
+	categoryRepo entity.CategoryRepository
+	logger       *zap.Logger
+	tracer       *tracing.Tracer
+}
+
+func NewPromotionService(
+	repo entity.PromotionRepository,
+	productRepo entity.ProductRepository,
+	brandRepo entity.BrandRepository,
+	categoryRepo entity.CategoryRepository,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) entity.PromotionService {
+	return &promotionService{
+		repo:         repo,
+		productRepo:  productRepo,
+		brandRepo:    brandRepo,
+		categoryRepo: categoryRepo,
+		logger:       logger,
+		tracer:       tracer,
+	}
+}
+
+func (s *promotionService) Create(ctx context.Context, req entity.CreatePromotionRequest) (*entity.PromotionResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.promotion.Create")
+	defer span.End()
+
+	if err := req.Validate(); err != nil {
+		s.logger.Error("failed to validate promotion request", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.ensureScopeExists(ctx, req.ScopeType, req.ScopeID); err != nil {
+		return nil, err
+	}
+
+	promotion := req.ToPromotionEntity()
+	if err := s.repo.Create(ctx, promotion); err != nil {
+		s.logger.Error("failed to create promotion", zap.Error(err))
+		return nil, err
+	}
+
+	return promotion.ToResponseDTO(), nil
+}
+
+func (s *promotionService) GetByID(ctx context.Context, id uuid.UUID) (*entity.PromotionResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.promotion.GetByID")
+	defer span.End()
+
+	promotion, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get promotion", zap.Error(err))
+		return nil, err
+	}
+	if promotion == nil {
+		return nil, entity.ErrPromotionNotFound
+	}
+
+	return promotion.ToResponseDTO(), nil
+}
+
+func (s *promotionService) GetAll(ctx context.Context, filter entity.PromotionFilterRequest) ([]entity.PromotionResponse, int64, error) {
+	ctx, span := s.tracer.Start(ctx, "service.promotion.GetAll")
+	defer span.End()
+
+	promotions, count, err := s.repo.GetAllWithFilter(ctx, filter.ToPromotionFilterRepo())
+	if err != nil {
+		s.logger.Error("failed to get promotions", zap.Error(err))
+		return nil, 0, err
+	}
+
+	responses := make([]entity.PromotionResponse, len(promotions))
+	for i, promotion := range promotions {
+		responses[i] = *promotion.ToResponseDTO()
+	}
+
+	return responses, count, nil
+}
+
+func (s *promotionService) Update(ctx context.Context, id uuid.UUID, req entity.UpdatePromotionRequest) (*entity.PromotionResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.promotion.Update")
+	defer span.End()
+
+	if err := req.Validate(); err != nil {
+		s.logger.Error("failed to validate promotion request", zap.Error(err))
+		return nil, err
+	}
+
+	promotion, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get promotion", zap.Error(err))
+		return nil, err
+	}
+	if promotion == nil {
+		return nil, entity.ErrPromotionNotFound
+	}
+
+	if err := s.ensureScopeExists(ctx, req.ScopeType, req.ScopeID); err != nil {
+		return nil, err
+	}
+
+	promotion.UpdateFromRequest(req)
+	if err := s.repo.Update(ctx, promotion); err != nil {
+		s.logger.Error("failed to update promotion", zap.Error(err))
+		return nil, err
+	}
+
+	return promotion.ToResponseDTO(), nil
+}
+
+func (s *promotionService) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "service.promotion.Delete")
+	defer span.End()
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete promotion", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *promotionService) ensureScopeExists(ctx context.Context, scopeType string, scopeID uuid.UUID) error {
+	var (
+		exists bool
+		err    error
+	)
+	switch scopeType {
+	case entity.PromotionScopeProduct:
+		exists, err = s.productRepo.ExistsByID(ctx, scopeID)
+	case entity.PromotionScopeBrand:
+		exists, err = s.brandRepo.ExistsByID(ctx, scopeID)
+	case entity.PromotionScopeCategory:
+		exists, err = s.categoryRepo.ExistsByID(ctx, scopeID)
+	}
+	if err != nil {
+		s.logger.Error("failed to check promotion scope existence", zap.Error(err))
+		return err
+	}
+	if !exists {
+		return entity.ErrPromotionScopeNotFound
+	}
+	return nil
+}
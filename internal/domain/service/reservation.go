@@ -0,0 +1,121 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"context"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"time"
+)
+
+type ReservationOptions struct {
+	DefaultTTL time.Duration
+	MaxTTL     time.Duration
+}
+
+type reservationService struct {
+	repo   entity.ReservationRepository
+	opts   ReservationOptions
+	logger *zap.Logger
+	tracer *tracing.Tracer
+}
+
+func NewReservationService(
+	repo entity.ReservationRepository,
+	opts ReservationOptions,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) entity.ReservationService {
+	return &reservationService{
+		repo:   repo,
+		opts:   opts,
+		logger: logger,
+		tracer: tracer,
+	}
+}
+
+func (s *reservationService) Create(ctx context.Context, productID uuid.UUID, req entity.CreateReservationRequest) (*entity.ReservationResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.reservation.Create")
+	defer span.End()
+
+	if err := req.Validate(); err != nil {
+		s.logger.Error("failed to validate reservation request", zap.Error(err))
+		return nil, err
+	}
+
+	ttl := s.opts.DefaultTTL
+	if req.TTLSeconds > 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+	if s.opts.MaxTTL > 0 && ttl > s.opts.MaxTTL {
+		return nil, entity.ErrReservationTTLTooLong
+	}
+
+	reservation := req.ToReservationEntity(productID, time.Now().Add(ttl))
+	if err := s.repo.Create(ctx, reservation); err != nil {
+		s.logger.Error("failed to create reservation", zap.Error(err))
+		return nil, err
+	}
+
+	return reservation.ToResponseDTO(), nil
+}
+
+func (s *reservationService) GetByID(ctx context.Context, id uuid.UUID) (*entity.ReservationResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.reservation.GetByID")
+	defer span.End()
+
+	reservation, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get reservation", zap.Error(err))
+		return nil, err
+	}
+	if reservation == nil {
+		return nil, entity.ErrReservationNotFound
+	}
+
+	return reservation.ToResponseDTO(), nil
+}
+
+func (s *reservationService) Confirm(ctx context.Context, id uuid.UUID) (*entity.ReservationResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.reservation.Confirm")
+	defer span.End()
+
+	reservation, err := s.repo.Confirm(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to confirm reservation", zap.Error(err))
+		return nil, err
+	}
+
+	return reservation.ToResponseDTO(), nil
+}
+
+func (s *reservationService) Release(ctx context.Context, id uuid.UUID) (*entity.ReservationResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.reservation.Release")
+	defer span.End()
+
+	reservation, err := s.repo.Release(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to release reservation", zap.Error(err))
+		return nil, err
+	}
+
+	return reservation.ToResponseDTO(), nil
+}
+
+func (s *reservationService) ExpireStale(ctx context.Context) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "service.reservation.ExpireStale")
+	defer span.End()
+
+	expired, err := s.repo.ExpireStale(ctx, time.Now())
+	if err != nil {
+		s.logger.Error("failed to expire stale reservations", zap.Error(err))
+		return 0, err
+	}
+
+	if expired > 0 {
+		s.logger.Info("expired stale reservations", zap.Int64("count", expired))
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"Unnispick/pkg/slug"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+)
+
+// maxSlugSuffix bounds the numbered slugs tried before falling back to a random suffix
+const maxSlugSuffix = 20
+
+// uniqueSlug derives a slug from name and numbers it until taken reports it free. Names with
+// nothing to transliterate fall back to the entity type.
+func uniqueSlug(ctx context.Context, name, fallback string, taken func(ctx context.Context, slug string) (bool, error)) (string, error) {
+	base := slug.Make(name)
+	if base == "" {
+		base = fallback
+	}
+
+	for n := 1; n <= maxSlugSuffix; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = slug.WithSuffix(base, n)
+		}
+
+		inUse, err := taken(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !inUse {
+			return candidate, nil
+		}
+	}
+
+	return fmt.Sprintf("%s-%s", base, uuid.NewString()[:8]), nil
+}
+
+// resolveSlug returns the requested slug, or a free one derived from name when none is requested.
+// A requested slug that taken reports in use fails with takenErr.
+func resolveSlug(ctx context.Context, name, requested, fallback string, takenErr error, taken func(ctx context.Context, slug string) (bool, error)) (string, error) {
+	if requested == "" {
+		return uniqueSlug(ctx, name, fallback, taken)
+	}
+
+	inUse, err := taken(ctx, requested)
+	if err != nil {
+		return "", err
+	}
+	if inUse {
+		return "", fmt.Errorf("%w: %s", takenErr, requested)
+	}
+	return requested, nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"context"
+	"go.uber.org/zap"
+	"strings"
+)
+
+type SuggestionOptions struct {
+	// Threshold is the word similarity from 0 to 1 a name needs to be suggested
+	Threshold float64
+}
+
+type suggestionService struct {
+	repo   entity.SuggestionRepository
+	opts   SuggestionOptions
+	logger *zap.Logger
+	tracer *tracing.Tracer
+}
+
+func NewSuggestionService(
+	repo entity.SuggestionRepository,
+	opts SuggestionOptions,
+	logger *zap.Logger,
+	tracer *tracing.Tracer,
+) entity.SuggestionService {
+	return &suggestionService{
+		repo:   repo,
+		opts:   opts,
+		logger: logger,
+		tracer: tracer,
+	}
+}
+
+// Suggest returns the brands and products whose name resembles the text, tolerating typos. A limit
+// outside 1 to MaxSuggestionLimit falls back to the default.
+func (s *suggestionService) Suggest(ctx context.Context, text string, limit int) ([]entity.Suggestion, error) {
+	ctx, span := s.tracer.Start(ctx, "service.suggestion.Suggest")
+	defer span.End()
+
+	if limit < 1 || limit > entity.MaxSuggestionLimit {
+		limit = entity.DefaultSuggestionLimit
+	}
+	threshold := s.opts.Threshold
+	if threshold <= 0 || threshold > 1 {
+		threshold = entity.DefaultSuggestionThreshold
+	}
+
+	suggestions, err := s.repo.Suggest(ctx, strings.TrimSpace(text), threshold, limit)
+	if err != nil {
+		s.logger.Error("failed to get suggestions", zap.Error(err))
+		return nil, err
+	}
+
+	return suggestions, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/infra/tracing"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type tagService struct {
+	repo   entity.TagRepository
+	logger *zap.Logger
+	tracer *tracing.Tracer
+}
+
+func NewTagService(repo entity.TagRepository, logger *zap.Logger, tracer *tracing.Tracer) entity.TagService {
+	return &tagService{
+		repo:   repo,
+		logger: logger,
+		tracer: tracer,
+	}
+}
+
+func (s *tagService) Create(ctx context.Context, req entity.CreateTagRequest) (*entity.TagResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.tag.Create")
+	defer span.End()
+
+	if err := req.Validate(); err != nil {
+		s.logger.Error("failed to validate tag request", zap.Error(err))
+		return nil, err
+	}
+
+	tag := req.ToTagEntity()
+	exists, err := s.repo.ExistsByName(ctx, tag.TagName)
+	if err != nil {
+		s.logger.Error("failed to check tag existence", zap.Error(err))
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("tag with name %s already exists", tag.TagName)
+	}
+
+	if err := s.repo.Create(ctx, tag); err != nil {
+		s.logger.Error("failed to create tag", zap.Error(err))
+		return nil, err
+	}
+
+	return tag.ToResponseDTO(), nil
+}
+
+func (s *tagService) GetAll(ctx context.Context, filter entity.TagFilterRequest) ([]entity.TagResponse, int64, error) {
+	ctx, span := s.tracer.Start(ctx, "service.tag.GetAll")
+	defer span.End()
+
+	tags, count, err := s.repo.GetAllWithFilter(ctx, filter.ToTagFilterRepo())
+	if err != nil {
+		s.logger.Error("failed to get tags", zap.Error(err))
+		return nil, 0, err
+	}
+
+	responses := make([]entity.TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = *tag.ToResponseDTO()
+	}
+
+	return responses, count, nil
+}
+
+func (s *tagService) Update(ctx context.Context, id uuid.UUID, req entity.UpdateTagRequest) (*entity.TagResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.tag.Update")
+	defer span.End()
+
+	if err := req.Validate(); err != nil {
+		s.logger.Error("failed to validate tag request", zap.Error(err))
+		return nil, err
+	}
+
+	tag, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get tag", zap.Error(err))
+		return nil, err
+	}
+	if tag == nil {
+		return nil, fmt.Errorf("tag not found")
+	}
+
+	if name := entity.NormalizeTagName(req.TagName); name != tag.TagName {
+		exists, err := s.repo.ExistsByName(ctx, name)
+		if err != nil {
+			s.logger.Error("failed to check tag existence", zap.Error(err))
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("tag with name %s already exists", name)
+		}
+	}
+
+	tag.UpdateFromRequest(req)
+	if err := s.repo.Update(ctx, tag); err != nil {
+		s.logger.Error("failed to update tag", zap.Error(err))
+		return nil, err
+	}
+
+	return tag.ToResponseDTO(), nil
+}
+
+func (s *tagService) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "service.tag.Delete")
+	defer span.End()
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete tag", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
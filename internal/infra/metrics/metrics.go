@@ -175,6 +175,20 @@ func (m *Metrics) RecordProductDeleted(ctx context.Context) {
 	m.productDeleted.Add(ctx, 1)
 }
 
+// RecordProductsCreated, RecordProductsUpdated and RecordProductsDeleted count the products a
+// bulk request wrote
+func (m *Metrics) RecordProductsCreated(ctx context.Context, count int) {
+	m.productCreated.Add(ctx, int64(count))
+}
+
+func (m *Metrics) RecordProductsUpdated(ctx context.Context, count int) {
+	m.productUpdated.Add(ctx, int64(count))
+}
+
+func (m *Metrics) RecordProductsDeleted(ctx context.Context, count int) {
+	m.productDeleted.Add(ctx, int64(count))
+}
+
 func (m *Metrics) RecordBrandCreated(ctx context.Context) {
 	m.brandCreated.Add(ctx, 1)
 }
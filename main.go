@@ -2,30 +2,56 @@ package main
 
 import (
 	"Unnispick/cmd/api"
+	"Unnispick/internal/domain/entity"
+	"Unnispick/internal/domain/repository"
+	"Unnispick/internal/infra/tracing"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"io"
 	"log"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
+// defaultTrashRetention is how long soft deleted rows are kept when TRASH_RETENTION is not set
+const defaultTrashRetention = 30 * 24 * time.Hour
+
 func main() {
 	var migrationDir string
 	var dbURL string
 	var command string
+	var retention time.Duration
+	var file string
+	var dryRun bool
+	var createBrands bool
+	var format string
+	var filter string
 
 	// Parse command line arguments
 	flag.StringVar(&migrationDir, "path", "migrations", "Directory where migration files are stored")
 	flag.StringVar(&dbURL, "db", os.Getenv("DATABASE_URL"), "Database connection string (or use DATABASE_URL env var)")
-	flag.StringVar(&command, "command", "", "Command to run (migrate/api)")
+	flag.StringVar(&command, "command", "", "Command to run (migrate/api/reconcile/purge/import/export)")
+	flag.DurationVar(&retention, "retention", trashRetentionFromEnv(), "How long purge keeps soft deleted rows (or use TRASH_RETENTION env var)")
+	flag.StringVar(&file, "file", "", "CSV file to import, or file to export to (default: stdout)")
+	flag.BoolVar(&dryRun, "dry-run", false, "Check the import file without writing anything")
+	flag.BoolVar(&createBrands, "create-brands", false, "Create brands the import file names that do not exist yet")
+	flag.StringVar(&format, "format", entity.ExportFormatCSV, "Export format (csv/ndjson)")
+	flag.StringVar(&filter, "filter", "", "Product list filters to export as a query string, e.g. status=all&brand_id=...")
 	flag.Parse()
 
 	if command == "" {
-		log.Fatal("Command is required (migrate/api)")
+		log.Fatal("Command is required (migrate/api/reconcile/purge/import/export)")
 	}
 
 	switch strings.ToLower(command) {
@@ -33,6 +59,14 @@ func main() {
 		handleMigration(migrationDir, dbURL)
 	case "api":
 		api.StartAPI()
+	case "reconcile":
+		handleReconcile(dbURL)
+	case "purge":
+		handlePurge(dbURL, retention)
+	case "import":
+		handleImport(dbURL, file, entity.ProductImportOptions{DryRun: dryRun, CreateBrands: createBrands})
+	case "export":
+		handleExport(dbURL, file, strings.ToLower(format), filter)
 	default:
 		log.Fatalf("Invalid command: %s", command)
 	}
@@ -86,3 +120,202 @@ func handleMigration(migrationDir, dbURL string) {
 		log.Fatalf("Invalid migration direction: %s", direction)
 	}
 }
+
+func openDatabase(dbURL string) *gorm.DB {
+	if dbURL == "" {
+		log.Fatal("Database URL is required")
+	}
+
+	db, err := gorm.Open(postgres.Open(dbURL), &gorm.Config{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return db
+}
+
+func handleReconcile(dbURL string) {
+	db := openDatabase(dbURL)
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	repo := repository.NewStockMovementRepository(db, tracing.NewTracer(zap.NewNop()))
+	discrepancies, err := repo.Reconcile(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(discrepancies) == 0 {
+		log.Println("Stock ledger is consistent with product quantities")
+		return
+	}
+
+	for _, d := range discrepancies {
+		log.Printf("Mismatch for product %s (%s): quantity=%d ledger=%d",
+			d.ProductID, d.ProductName, d.Quantity, d.LedgerQuantity)
+	}
+	log.Fatalf("Found %d product(s) whose stock ledger does not match quantity", len(discrepancies))
+}
+
+func trashRetentionFromEnv() time.Duration {
+	value := os.Getenv("TRASH_RETENTION")
+	if value == "" {
+		return defaultTrashRetention
+	}
+
+	retention, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid TRASH_RETENTION: %v", err)
+	}
+	return retention
+}
+
+func handlePurge(dbURL string, retention time.Duration) {
+	if retention < 0 {
+		log.Fatal("Retention must not be negative")
+	}
+
+	// The storage is set up before anything is purged so the image files never outlive their rows
+	storage, err := api.InitializeMediaStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db := openDatabase(dbURL)
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	ctx := context.Background()
+	tracer := tracing.NewTracer(zap.NewNop())
+	deletedBefore := time.Now().Add(-retention)
+
+	// Products go first so brands they kept alive can be purged in the same run
+	products, imageKeys, err := repository.NewProductRepository(db, tracer).Purge(ctx, deletedBefore)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The rows are gone, a file that cannot be removed is reported for clean up by hand
+	for _, key := range imageKeys {
+		if err := storage.Delete(ctx, key); err != nil {
+			log.Printf("Failed to remove stored product image %s: %v", key, err)
+		}
+	}
+
+	brands, err := repository.NewBrandRepository(db, tracer).Purge(ctx, deletedBefore)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Purged %d product(s) and %d brand(s) deleted before %s",
+		products, brands, deletedBefore.Format(time.RFC3339))
+}
+
+func handleImport(dbURL, path string, opts entity.ProductImportOptions) {
+	if path == "" {
+		log.Fatal("Import file is required (-file)")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	db := openDatabase(dbURL)
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	importer := api.InitializeProductImporter(db, zap.NewNop())
+	report, err := importer.Import(context.Background(), file, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, name := range report.CreatedBrands {
+		if report.DryRun {
+			log.Printf("Brand %s would be created", name)
+		} else {
+			log.Printf("Brand %s created", name)
+		}
+	}
+	for _, row := range report.Rows {
+		if len(row.Errors) > 0 {
+			log.Printf("Row %d (%s): %s: %s", row.Row, row.ProductName, row.Status, strings.Join(row.Errors, "; "))
+		}
+	}
+
+	summary := fmt.Sprintf("%d product(s) to create, %d to update and %d row(s) failed", report.Created, report.Updated, report.Failed)
+	if !report.DryRun {
+		summary = fmt.Sprintf("Created %d product(s), updated %d and %d row(s) failed", report.Created, report.Updated, report.Failed)
+	}
+	if report.Failed > 0 {
+		log.Fatal(summary)
+	}
+	log.Println(summary)
+}
+
+func handleExport(dbURL, path, format, filter string) {
+	if !entity.IsExportFormat(format) {
+		log.Fatal(entity.ErrInvalidExportFormat)
+	}
+
+	query, err := url.ParseQuery(filter)
+	if err != nil {
+		log.Fatalf("Invalid filter: %v", err)
+	}
+
+	db := openDatabase(dbURL)
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	exporter := api.InitializeProductExporter(db, zap.NewNop())
+
+	var count int
+	export := func(out io.Writer) (err error) {
+		count, err = exporter.Export(context.Background(), entity.ParseProductFilter(query), format, out)
+		return err
+	}
+	if path == "" {
+		err = export(os.Stdout)
+	} else {
+		err = writeFileAtomically(path, export)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The summary goes to stderr so it stays out of an export written to stdout
+	log.Printf("Exported %d product(s)", count)
+}
+
+// writeFileAtomically writes to a temporary file next to path and renames it into place, so a
+// failed write never leaves a truncated file behind
+func writeFileAtomically(path string, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+
+	err = write(tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		// CreateTemp keeps the file private, an export is as readable as one os.Create makes
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,2 @@
+-- 000003_create_table_stock_movement.down.sql
+DROP TABLE IF EXISTS stock_movements;
@@ -0,0 +1,19 @@
+-- 000003_create_table_stock_movement.up.sql
+CREATE TABLE IF NOT EXISTS stock_movements
+(
+    id         UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    product_id UUID         NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    delta      INTEGER      NOT NULL CHECK (delta <> 0),
+    reason     VARCHAR(50)  NOT NULL,
+    reference  VARCHAR(255),
+    actor      VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_movements_product_created ON stock_movements (product_id, created_at DESC);
+
+-- Opening balance so the ledger of existing products matches their current quantity
+INSERT INTO stock_movements (product_id, delta, reason, actor)
+SELECT id, quantity, 'opening_balance', 'system'
+FROM products
+WHERE quantity <> 0;
@@ -0,0 +1,2 @@
+-- 000004_create_table_stock_reservation.down.sql
+DROP TABLE IF EXISTS stock_reservations;
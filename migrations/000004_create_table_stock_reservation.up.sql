@@ -0,0 +1,14 @@
+-- 000004_create_table_stock_reservation.up.sql
+CREATE TABLE IF NOT EXISTS stock_reservations
+(
+    id         UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    product_id UUID                     NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    quantity   INTEGER                  NOT NULL CHECK (quantity > 0),
+    status     VARCHAR(20)              NOT NULL DEFAULT 'pending',
+    reference  VARCHAR(255),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_reservations_pending ON stock_reservations (product_id, expires_at) WHERE status = 'pending';
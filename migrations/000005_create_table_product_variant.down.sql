@@ -0,0 +1,2 @@
+-- 000005_create_table_product_variant.down.sql
+DROP TABLE IF EXISTS product_variants;
@@ -0,0 +1,18 @@
+-- 000005_create_table_product_variant.up.sql
+CREATE TABLE IF NOT EXISTS product_variants
+(
+    id           UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    product_id   UUID           NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    sku          VARCHAR(100)   NOT NULL,
+    variant_name VARCHAR(255)   NOT NULL,
+    shade        VARCHAR(100),
+    size         VARCHAR(50),
+    volume       VARCHAR(50),
+    price        DECIMAL(15, 2) NOT NULL CHECK (price > 0),
+    quantity     INTEGER        NOT NULL CHECK (quantity >= 0),
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_product_variants_sku ON product_variants (sku);
+CREATE INDEX idx_product_variants_product_id ON product_variants (product_id);
@@ -0,0 +1,3 @@
+-- 000006_create_table_category.down.sql
+DROP TABLE IF EXISTS product_categories;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,23 @@
+-- 000006_create_table_category.up.sql
+CREATE TABLE IF NOT EXISTS categories
+(
+    id            UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    category_name VARCHAR(255) NOT NULL,
+    parent_id     UUID REFERENCES categories (id),
+    created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at    TIMESTAMP WITH TIME ZONE,
+    CHECK (parent_id <> id)
+);
+
+CREATE INDEX idx_categories_parent_id ON categories (parent_id);
+CREATE INDEX idx_categories_deleted_at ON categories (deleted_at);
+
+CREATE TABLE IF NOT EXISTS product_categories
+(
+    product_id  UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, category_id)
+);
+
+CREATE INDEX idx_product_categories_category_id ON product_categories (category_id);
@@ -0,0 +1,3 @@
+-- 000007_create_table_tag.down.sql
+DROP TABLE IF EXISTS product_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,19 @@
+-- 000007_create_table_tag.up.sql
+CREATE TABLE IF NOT EXISTS tags
+(
+    id         UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    tag_name   VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_tags_tag_name ON tags (tag_name);
+
+CREATE TABLE IF NOT EXISTS product_tags
+(
+    product_id UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    tag_id     UUID NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, tag_id)
+);
+
+CREATE INDEX idx_product_tags_tag_id ON product_tags (tag_id);
@@ -0,0 +1,3 @@
+-- 000008_create_table_ingredient.down.sql
+DROP TABLE IF EXISTS product_ingredients;
+DROP TABLE IF EXISTS ingredients;
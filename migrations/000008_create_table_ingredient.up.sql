@@ -0,0 +1,22 @@
+-- 000008_create_table_ingredient.up.sql
+CREATE TABLE IF NOT EXISTS ingredients
+(
+    id          UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    inci_name   VARCHAR(255) NOT NULL,
+    common_name VARCHAR(255),
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_ingredients_inci_name ON ingredients (LOWER(inci_name));
+
+CREATE TABLE IF NOT EXISTS product_ingredients
+(
+    product_id    UUID    NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    ingredient_id UUID    NOT NULL REFERENCES ingredients (id),
+    position      INTEGER NOT NULL CHECK (position > 0),
+    PRIMARY KEY (product_id, ingredient_id),
+    UNIQUE (product_id, position)
+);
+
+CREATE INDEX idx_product_ingredients_ingredient_id ON product_ingredients (ingredient_id);
@@ -0,0 +1,2 @@
+-- 000009_create_table_product_image.down.sql
+DROP TABLE IF EXISTS product_images;
@@ -0,0 +1,19 @@
+-- 000009_create_table_product_image.up.sql
+CREATE TABLE IF NOT EXISTS product_images
+(
+    id           UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    product_id   UUID         NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    storage_key  VARCHAR(512) NOT NULL,
+    url          TEXT         NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size         BIGINT       NOT NULL CHECK (size > 0),
+    position     INTEGER      NOT NULL CHECK (position > 0),
+    is_primary   BOOLEAN      NOT NULL    DEFAULT FALSE,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_product_images_product_id ON product_images (product_id, position);
+
+-- At most one primary image per product
+CREATE UNIQUE INDEX idx_product_images_primary ON product_images (product_id) WHERE is_primary;
@@ -0,0 +1,19 @@
+-- 000010_convert_price_to_money.down.sql
+-- Amounts are converted back assuming two minor unit digits, which holds for IDR
+DROP INDEX IF EXISTS idx_products_price;
+
+ALTER TABLE products ADD COLUMN price DECIMAL(15, 2);
+UPDATE products SET price = price_amount / 100.0;
+ALTER TABLE products
+    ALTER COLUMN price SET NOT NULL,
+    ADD CONSTRAINT products_price_check CHECK (price > 0),
+    DROP COLUMN price_amount,
+    DROP COLUMN price_currency;
+
+ALTER TABLE product_variants ADD COLUMN price DECIMAL(15, 2);
+UPDATE product_variants SET price = price_amount / 100.0;
+ALTER TABLE product_variants
+    ALTER COLUMN price SET NOT NULL,
+    ADD CONSTRAINT product_variants_price_check CHECK (price > 0),
+    DROP COLUMN price_amount,
+    DROP COLUMN price_currency;
@@ -0,0 +1,26 @@
+-- 000010_convert_price_to_money.up.sql
+-- Prices become an exact amount in minor units plus an ISO 4217 currency,
+-- existing prices were implicitly IDR which has two minor unit digits
+ALTER TABLE products
+    ADD COLUMN price_amount   BIGINT,
+    ADD COLUMN price_currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+UPDATE products SET price_amount = ROUND(price * 100);
+
+ALTER TABLE products
+    ALTER COLUMN price_amount SET NOT NULL,
+    ADD CONSTRAINT products_price_amount_check CHECK (price_amount > 0),
+    DROP COLUMN price;
+
+ALTER TABLE product_variants
+    ADD COLUMN price_amount   BIGINT,
+    ADD COLUMN price_currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+UPDATE product_variants SET price_amount = ROUND(price * 100);
+
+ALTER TABLE product_variants
+    ALTER COLUMN price_amount SET NOT NULL,
+    ADD CONSTRAINT product_variants_price_amount_check CHECK (price_amount > 0),
+    DROP COLUMN price;
+
+CREATE INDEX idx_products_price ON products (price_currency, price_amount);
@@ -0,0 +1,3 @@
+-- 000011_create_table_price_list.down.sql
+DROP TABLE IF EXISTS price_list_items;
+DROP TABLE IF EXISTS exchange_rates;
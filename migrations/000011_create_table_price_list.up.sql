@@ -0,0 +1,25 @@
+-- 000011_create_table_price_list.up.sql
+-- One unit of base_currency is worth rate units of quote_currency
+CREATE TABLE IF NOT EXISTS exchange_rates
+(
+    base_currency  CHAR(3)        NOT NULL,
+    quote_currency CHAR(3)        NOT NULL,
+    rate           NUMERIC(24, 12) NOT NULL CHECK (rate > 0),
+    created_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (base_currency, quote_currency),
+    CHECK (base_currency <> quote_currency)
+);
+
+-- Explicit product prices per currency, used instead of converting the base price
+CREATE TABLE IF NOT EXISTS price_list_items
+(
+    product_id UUID    NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    currency   CHAR(3) NOT NULL,
+    amount     BIGINT  NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, currency)
+);
+
+CREATE INDEX idx_price_list_items_currency ON price_list_items (currency);
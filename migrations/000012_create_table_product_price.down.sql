@@ -0,0 +1,2 @@
+-- 000012_create_table_product_price.down.sql
+DROP TABLE IF EXISTS product_prices;
@@ -0,0 +1,24 @@
+-- 000012_create_table_product_price.up.sql
+-- Applied rows form the price history of a product, the open one (effective_to IS NULL) is current.
+-- Rows with applied_at IS NULL are scheduled changes waiting for their effective_from.
+CREATE TABLE IF NOT EXISTS product_prices
+(
+    id             UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    product_id     UUID         NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    price_amount   BIGINT       NOT NULL CHECK (price_amount > 0),
+    price_currency CHAR(3)      NOT NULL,
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    effective_to   TIMESTAMP WITH TIME ZONE,
+    applied_at     TIMESTAMP WITH TIME ZONE,
+    actor          VARCHAR(255) NOT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE INDEX idx_product_prices_product_id ON product_prices (product_id, effective_from DESC);
+CREATE INDEX idx_product_prices_pending ON product_prices (effective_from) WHERE applied_at IS NULL;
+
+-- Start the history of existing products from their current price
+INSERT INTO product_prices (product_id, price_amount, price_currency, effective_from, applied_at, actor)
+SELECT id, price_amount, price_currency, created_at, created_at, 'system'
+FROM products;
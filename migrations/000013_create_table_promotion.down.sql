@@ -0,0 +1,2 @@
+-- 000013_create_table_promotion.down.sql
+DROP TABLE IF EXISTS promotions;
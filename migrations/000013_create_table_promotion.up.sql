@@ -0,0 +1,25 @@
+-- 000013_create_table_promotion.up.sql
+-- A promotion discounts every product in its scope while NOW() is within [starts_at, ends_at)
+CREATE TABLE IF NOT EXISTS promotions
+(
+    id                  UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    promotion_name      VARCHAR(255) NOT NULL,
+    discount_type       VARCHAR(20)  NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+    percentage          NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    amount_off_amount   BIGINT       NOT NULL DEFAULT 0,
+    amount_off_currency VARCHAR(3)   NOT NULL DEFAULT '',
+    scope_type          VARCHAR(20)  NOT NULL CHECK (scope_type IN ('product', 'brand', 'category')),
+    scope_id            UUID         NOT NULL,
+    starts_at           TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at             TIMESTAMP WITH TIME ZONE,
+    priority            INTEGER      NOT NULL DEFAULT 0,
+    stackable           BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (discount_type <> 'percentage' OR (percentage > 0 AND percentage <= 100)),
+    CHECK (discount_type <> 'fixed' OR amount_off_amount > 0),
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX idx_promotions_scope ON promotions (scope_type, scope_id);
+CREATE INDEX idx_promotions_period ON promotions (starts_at, ends_at);
@@ -0,0 +1,5 @@
+-- 000014_create_table_bundle_component.down.sql
+DROP TABLE IF EXISTS bundle_components;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS product_type;
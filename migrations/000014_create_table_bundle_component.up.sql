@@ -0,0 +1,16 @@
+-- 000014_create_table_bundle_component.up.sql
+-- A bundle has no stock of its own, its availability is derived from its components
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS product_type VARCHAR(20) NOT NULL DEFAULT 'simple'
+        CHECK (product_type IN ('simple', 'bundle'));
+
+CREATE TABLE IF NOT EXISTS bundle_components
+(
+    bundle_id    UUID    NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    component_id UUID    NOT NULL REFERENCES products (id) ON DELETE RESTRICT,
+    quantity     INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (bundle_id, component_id),
+    CHECK (bundle_id <> component_id)
+);
+
+CREATE INDEX idx_bundle_components_component_id ON bundle_components (component_id);
@@ -0,0 +1,11 @@
+-- 000015_add_brand_profile.down.sql
+DROP INDEX IF EXISTS idx_brands_country;
+DROP INDEX IF EXISTS idx_brands_slug;
+
+ALTER TABLE brands
+    DROP COLUMN IF EXISTS social_links,
+    DROP COLUMN IF EXISTS website,
+    DROP COLUMN IF EXISTS country,
+    DROP COLUMN IF EXISTS logo_url,
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,31 @@
+-- 000015_add_brand_profile.up.sql
+ALTER TABLE brands
+    ADD COLUMN IF NOT EXISTS slug         VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS description  TEXT          NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS logo_url     VARCHAR(2048) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS country      VARCHAR(2)    NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS website      VARCHAR(2048) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS social_links JSONB         NOT NULL DEFAULT '{}'::jsonb;
+
+-- Derive slugs for existing brands, suffixing names that collapse to the same slug
+WITH slugs AS (
+    SELECT id,
+           trim(BOTH '-' FROM regexp_replace(lower(brand_name), '[^a-z0-9]+', '-', 'g')) AS base
+    FROM brands
+),
+numbered AS (
+    SELECT id,
+           CASE WHEN base = '' THEN 'brand' ELSE base END AS base,
+           row_number() OVER (PARTITION BY base ORDER BY id) AS n
+    FROM slugs
+)
+UPDATE brands b
+SET slug = CASE WHEN numbered.n = 1 THEN numbered.base ELSE numbered.base || '-' || numbered.n END
+FROM numbered
+WHERE numbered.id = b.id;
+
+ALTER TABLE brands
+    ALTER COLUMN slug SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_brands_slug ON brands (slug);
+CREATE INDEX idx_brands_country ON brands (country);
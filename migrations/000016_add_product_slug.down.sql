@@ -0,0 +1,7 @@
+-- 000016_add_product_slug.down.sql
+DROP TABLE IF EXISTS slug_redirects;
+
+DROP INDEX IF EXISTS idx_products_slug;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS slug;
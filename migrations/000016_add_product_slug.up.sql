@@ -0,0 +1,39 @@
+-- 000016_add_product_slug.up.sql
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS slug VARCHAR(255);
+
+-- Derive slugs for existing products, suffixing names that collapse to the same slug
+WITH slugs AS (
+    SELECT id,
+           left(trim(BOTH '-' FROM regexp_replace(lower(unaccent(product_name)), '[^a-z0-9]+', '-', 'g')), 200) AS base
+    FROM products
+),
+numbered AS (
+    SELECT id,
+           CASE WHEN base = '' THEN 'product' ELSE base END AS base,
+           row_number() OVER (PARTITION BY base ORDER BY id) AS n
+    FROM slugs
+)
+UPDATE products p
+SET slug = CASE WHEN numbered.n = 1 THEN numbered.base ELSE numbered.base || '-' || numbered.n END
+FROM numbered
+WHERE numbered.id = p.id;
+
+ALTER TABLE products
+    ALTER COLUMN slug SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_products_slug ON products (slug);
+
+-- Slugs brands and products were renamed away from, resolved to the current slug
+CREATE TABLE IF NOT EXISTS slug_redirects
+(
+    entity_type VARCHAR(20)  NOT NULL CHECK (entity_type IN ('brand', 'product')),
+    slug        VARCHAR(255) NOT NULL,
+    entity_id   UUID         NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entity_type, slug)
+);
+
+CREATE INDEX idx_slug_redirects_entity ON slug_redirects (entity_type, entity_id);
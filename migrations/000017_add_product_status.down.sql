@@ -0,0 +1,5 @@
+-- 000017_add_product_status.down.sql
+DROP INDEX IF EXISTS idx_products_status;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,10 @@
+-- 000017_add_product_status.up.sql
+-- Products created before lifecycle states were live, new ones start as drafts
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('draft', 'active', 'discontinued', 'archived'));
+
+ALTER TABLE products
+    ALTER COLUMN status SET DEFAULT 'draft';
+
+CREATE INDEX idx_products_status ON products (status);
@@ -0,0 +1,2 @@
+-- 000018_create_table_audit_log.down.sql
+DROP TABLE IF EXISTS audit_logs;
@@ -0,0 +1,17 @@
+-- 000018_create_table_audit_log.up.sql
+-- Who changed which catalog entity and how, changes maps each field to its old and new value
+CREATE TABLE IF NOT EXISTS audit_logs
+(
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor       VARCHAR(255) NOT NULL,
+    action      VARCHAR(50)  NOT NULL,
+    entity_type VARCHAR(50)  NOT NULL,
+    entity_id   UUID         NOT NULL,
+    changes     JSONB        NOT NULL DEFAULT '{}'::jsonb,
+    trace_id    VARCHAR(32)  NOT NULL DEFAULT '',
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs (entity_type, entity_id, created_at DESC);
+CREATE INDEX idx_audit_logs_actor ON audit_logs (actor, created_at DESC);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs (created_at DESC);
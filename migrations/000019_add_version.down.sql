@@ -0,0 +1,6 @@
+-- 000019_add_version.down.sql
+ALTER TABLE products
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE brands
+    DROP COLUMN IF EXISTS version;
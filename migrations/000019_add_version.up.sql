@@ -0,0 +1,7 @@
+-- 000019_add_version.up.sql
+-- Every write bumps the version so a client can tell whether the row changed since it read it
+ALTER TABLE brands
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
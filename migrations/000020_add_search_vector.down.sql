@@ -0,0 +1,16 @@
+-- 000020_add_search_vector.down.sql
+DROP TRIGGER IF EXISTS brands_product_search_vectors ON brands;
+DROP FUNCTION IF EXISTS brands_refresh_product_search_vectors();
+
+DROP TRIGGER IF EXISTS products_search_vector ON products;
+DROP FUNCTION IF EXISTS products_refresh_search_vector();
+
+DROP INDEX IF EXISTS idx_products_search_vector;
+ALTER TABLE products
+    DROP COLUMN IF EXISTS search_vector;
+
+DROP FUNCTION IF EXISTS product_search_vector(TEXT, TEXT, TEXT);
+
+DROP INDEX IF EXISTS idx_brands_search_vector;
+ALTER TABLE brands
+    DROP COLUMN IF EXISTS search_vector;
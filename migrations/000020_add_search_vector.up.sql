@@ -0,0 +1,68 @@
+-- 000020_add_search_vector.up.sql
+-- Text search vectors use the simple configuration, brand and product names are proper nouns in
+-- several languages and are only lowercased, never stemmed
+ALTER TABLE brands
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', brand_name), 'A') ||
+        setweight(to_tsvector('simple', description), 'C')
+        ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_brands_search_vector ON brands USING GIN (search_vector);
+
+-- A product is found by its name and the name and description of its brand. A generated column
+-- cannot read the brand row, so triggers keep the vector in step with both tables.
+CREATE OR REPLACE FUNCTION product_search_vector(product_name TEXT, brand_name TEXT, brand_description TEXT)
+    RETURNS TSVECTOR AS
+$$
+SELECT setweight(to_tsvector('simple', COALESCE(product_name, '')), 'A') ||
+       setweight(to_tsvector('simple', COALESCE(brand_name, '')), 'B') ||
+       setweight(to_tsvector('simple', COALESCE(brand_description, '')), 'C')
+$$ LANGUAGE SQL IMMUTABLE;
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+UPDATE products p
+SET search_vector = product_search_vector(p.product_name, b.brand_name, b.description)
+FROM brands b
+WHERE b.id = p.brand_id;
+
+CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN (search_vector);
+
+CREATE OR REPLACE FUNCTION products_refresh_search_vector()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    NEW.search_vector := product_search_vector(
+            NEW.product_name,
+            (SELECT brand_name FROM brands WHERE id = NEW.brand_id),
+            (SELECT description FROM brands WHERE id = NEW.brand_id)
+        );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER products_search_vector
+    BEFORE INSERT OR UPDATE OF product_name, brand_id
+    ON products
+    FOR EACH ROW
+EXECUTE FUNCTION products_refresh_search_vector();
+
+-- Renaming a brand or changing its description moves the vectors of all its products
+CREATE OR REPLACE FUNCTION brands_refresh_product_search_vectors()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    UPDATE products
+    SET search_vector = product_search_vector(product_name, NEW.brand_name, NEW.description)
+    WHERE brand_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER brands_product_search_vectors
+    AFTER UPDATE OF brand_name, description
+    ON brands
+    FOR EACH ROW
+    WHEN (OLD.brand_name IS DISTINCT FROM NEW.brand_name OR OLD.description IS DISTINCT FROM NEW.description)
+EXECUTE FUNCTION brands_refresh_product_search_vectors();
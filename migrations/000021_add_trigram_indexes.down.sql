@@ -0,0 +1,3 @@
+-- 000021_add_trigram_indexes.down.sql
+DROP INDEX IF EXISTS idx_products_product_name_trgm;
+DROP INDEX IF EXISTS idx_brands_brand_name_trgm;
@@ -0,0 +1,7 @@
+-- 000021_add_trigram_indexes.up.sql
+-- Trigram indexes serve the typo tolerant name suggestions, they back the <% word similarity
+-- operator
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_brands_brand_name_trgm ON brands USING GIN (brand_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_products_product_name_trgm ON products USING GIN (product_name gin_trgm_ops);
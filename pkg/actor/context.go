@@ -0,0 +1,26 @@
+package actor
+
+import "context"
+
+const (
+	// Header is the request header used to identify who performed a change
+	Header = "X-Actor"
+	// System is used when no actor is attached to the context
+	System = "system"
+)
+
+type contextKey struct{}
+
+func WithActor(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, name)
+}
+
+func FromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(contextKey{}).(string); ok && name != "" {
+		return name
+	}
+	return System
+}
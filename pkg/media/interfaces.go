@@ -0,0 +1,19 @@
+package media
+
+import (
+	"context"
+	"io"
+)
+
+// MediaStorage stores uploaded files under a key and knows the public URL they are served from
+type MediaStorage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// FileServer is implemented by storages whose files are served by this API itself
+type FileServer interface {
+	MountPath() string
+	Dir() string
+}
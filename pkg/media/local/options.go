@@ -0,0 +1,6 @@
+package local
+
+type Options struct {
+	Root    string
+	BaseURL string
+}
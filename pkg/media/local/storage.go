@@ -0,0 +1,98 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type Storage struct {
+	root    string
+	baseURL string
+}
+
+func NewStorage(opts Options) (*Storage, error) {
+	if err := os.MkdirAll(opts.Root, 0o755); err != nil {
+		return nil, fmt.Errorf("creating media root: %w", err)
+	}
+
+	return &Storage{
+		root:    opts.Root,
+		baseURL: strings.TrimRight(opts.BaseURL, "/"),
+	}, nil
+}
+
+func (s *Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating media directory: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see a partial upload
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("creating media file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing media file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing media file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("storing media file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("deleting media file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) URL(key string) string {
+	return s.baseURL + "/" + (&url.URL{Path: key}).EscapedPath()
+}
+
+// MountPath is the URL path the files are served from, taken from the configured base URL
+func (s *Storage) MountPath() string {
+	u, err := url.Parse(s.baseURL)
+	if err != nil || u.Path == "" {
+		return "/"
+	}
+	return u.Path
+}
+
+func (s *Storage) Dir() string {
+	return s.root
+}
+
+// path resolves a key inside the media root, rejecting keys that would escape it
+func (s *Storage) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" {
+		return "", fmt.Errorf("invalid media key %q", key)
+	}
+	return filepath.Join(s.root, cleaned), nil
+}
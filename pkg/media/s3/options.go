@@ -0,0 +1,13 @@
+package s3
+
+type Options struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PublicURL is the base the objects are served from, defaults to the bucket URL
+	PublicURL string
+	// UsePathStyle addresses the bucket as endpoint/bucket, which local stand-ins like MinIO expect
+	UsePathStyle bool
+}
@@ -0,0 +1,102 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Storage talks to an S3 compatible object store over its REST API
+type Storage struct {
+	opts     Options
+	endpoint *url.URL
+	client   *http.Client
+}
+
+func NewStorage(opts Options) (*Storage, error) {
+	endpoint, err := url.Parse(strings.TrimRight(opts.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", opts.Endpoint)
+	}
+	if opts.Bucket == "" {
+		return nil, fmt.Errorf("s3 bucket is required")
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+
+	return &Storage{
+		opts:     opts,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (s *Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	// The payload is hashed for the signature, so it is buffered once instead of streamed
+	payload, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("reading media payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("building s3 request: %w", err)
+	}
+	req.ContentLength = int64(len(payload))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	return s.do(req, hashHex(payload))
+}
+
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return fmt.Errorf("building s3 request: %w", err)
+	}
+
+	return s.do(req, hashHex(nil))
+}
+
+func (s *Storage) URL(key string) string {
+	base := strings.TrimRight(s.opts.PublicURL, "/")
+	if base == "" {
+		return s.objectURL(key)
+	}
+	return base + "/" + (&url.URL{Path: key}).EscapedPath()
+}
+
+func (s *Storage) do(req *http.Request, payloadHash string) error {
+	s.sign(req, payloadHash, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling s3: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("s3 %s %s failed with status %d: %s", req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
+
+func (s *Storage) objectURL(key string) string {
+	u := *s.endpoint
+	if s.opts.UsePathStyle {
+		u.Path = u.Path + "/" + s.opts.Bucket + "/" + key
+	} else {
+		u.Host = s.opts.Bucket + "." + u.Host
+		u.Path = u.Path + "/" + key
+	}
+	return u.String()
+}
@@ -0,0 +1,108 @@
+// Package mergepatch applies JSON merge patches as described by RFC 7396
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ContentType is the media type of a JSON merge patch
+const ContentType = "application/merge-patch+json"
+
+var ErrNotObject = errors.New("merge patch must be a JSON object")
+
+// Patch is a parsed merge patch. A member set to null removes the member from the target,
+// an object is merged into the target member and any other value replaces it
+type Patch map[string]interface{}
+
+// Parse reads a merge patch document, which must be a JSON object to patch a resource
+func Parse(data []byte) (Patch, error) {
+	var doc interface{}
+	if err := decode(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+
+	patch, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, ErrNotObject
+	}
+	return patch, nil
+}
+
+// Fields lists the top-level members the patch changes, in name order
+func (p Patch) Fields() []string {
+	fields := make([]string, 0, len(p))
+	for field := range p {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Has reports whether the patch changes the top-level member
+func (p Patch) Has(field string) bool {
+	_, ok := p[field]
+	return ok
+}
+
+// Apply merges the patch into v, a pointer to a value that round-trips through JSON. Removed
+// members end up as their zero value and members v does not know are rejected
+func (p Patch) Apply(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode merge patch target: %w", err)
+	}
+
+	var target interface{}
+	if err := decode(data, &target); err != nil {
+		return fmt.Errorf("failed to decode merge patch target: %w", err)
+	}
+
+	merged, err := json.Marshal(merge(target, map[string]interface{}(p)))
+	if err != nil {
+		return fmt.Errorf("failed to encode patched document: %w", err)
+	}
+
+	// Start from the zero value so members removed by the patch do not keep their old value
+	value := reflect.ValueOf(v).Elem()
+	value.Set(reflect.Zero(value.Type()))
+
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid merge patch: %w", err)
+	}
+	return nil
+}
+
+func merge(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = merge(targetObject[name], value)
+	}
+	return targetObject
+}
+
+// decode keeps numbers as written so large amounts are not rounded through float64
+func decode(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,261 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// DefaultCurrency is used when a price is given without a currency
+const DefaultCurrency = "IDR"
+
+// exponents holds the number of minor unit digits of each supported ISO 4217 currency
+var exponents = map[string]int{
+	"IDR": 2,
+	"KRW": 0,
+	"USD": 2,
+	"EUR": 2,
+	"SGD": 2,
+	"MYR": 2,
+	"JPY": 0,
+}
+
+// Money is an exact amount in the minor units of its currency, so 460600.50 IDR is Amount 46060050
+type Money struct {
+	Amount   int64  `gorm:"column:amount;type:bigint;not null"`
+	Currency string `gorm:"column:currency;type:char(3);not null"`
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// IsSupported reports whether the currency code is known
+func IsSupported(currency string) bool {
+	_, ok := exponents[strings.ToUpper(currency)]
+	return ok
+}
+
+// Exponent returns the number of minor unit digits of the currency
+func Exponent(currency string) (int, error) {
+	exponent, ok := exponents[strings.ToUpper(currency)]
+	if !ok {
+		return 0, fmt.Errorf("unsupported currency %q", currency)
+	}
+	return exponent, nil
+}
+
+// Parse reads a decimal string like "460600.50" in the given currency without going through a float
+func Parse(value, currency string) (Money, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	exponent, err := Exponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "-"), "+")
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" && fraction == "" {
+		return Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	if len(fraction) > exponent {
+		return Money{}, fmt.Errorf("amount %q has more than %d decimal places for %s", value, exponent, currency)
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	var amount int64
+	for _, r := range whole + fraction {
+		if r < '0' || r > '9' {
+			return Money{}, fmt.Errorf("invalid amount %q", value)
+		}
+		if amount > (math.MaxInt64-int64(r-'0'))/10 {
+			return Money{}, fmt.Errorf("amount %q is too large", value)
+		}
+		amount = amount*10 + int64(r-'0')
+	}
+
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// String formats the amount as a decimal string with the currency's minor unit digits
+func (m Money) String() string {
+	exponent, err := Exponent(m.Currency)
+	if err != nil {
+		exponent = 2
+	}
+
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := fmt.Sprintf("%0*d", exponent+1, amount)
+	if exponent == 0 {
+		return sign + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+type moneyJSON struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency string          `json:"currency"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{
+		Amount:   m.String(),
+		Currency: m.Currency,
+	})
+}
+
+// UnmarshalJSON accepts {"amount": "460600.50", "currency": "IDR"}, or a bare decimal
+// string or number in the default currency
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	currency := DefaultCurrency
+	raw := data
+	if len(data) > 0 && data[0] == '{' {
+		var object moneyJSON
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+		if object.Currency != "" {
+			currency = object.Currency
+		}
+		raw = bytes.TrimSpace(object.Amount)
+	}
+
+	value, err := decimalText(raw)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := Parse(value, currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// decimalText returns the literal digits of a JSON string or number, so numbers never pass through float64
+func decimalText(raw []byte) (string, error) {
+	if len(raw) == 0 {
+		return "", fmt.Errorf("amount is required")
+	}
+	if raw[0] == '"' {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return "", err
+		}
+		return value, nil
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(raw, &number); err != nil {
+		return "", fmt.Errorf("invalid amount %s", raw)
+	}
+	if strings.ContainsAny(number.String(), "eE") {
+		return "", fmt.Errorf("amount %s must not use exponent notation", raw)
+	}
+	return number.String(), nil
+}
+
+// ParseRate reads a positive decimal exchange rate like "0.0853" exactly
+func ParseRate(value string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok || rate.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid exchange rate %q", value)
+	}
+	return rate, nil
+}
+
+// Convert multiplies the amount by rate, one unit of m's currency being worth rate units of
+// the target currency, and rounds half away from zero to the target's minor units
+func (m Money) Convert(rate *big.Rat, currency string) (Money, error) {
+	currency = strings.ToUpper(currency)
+	if currency == m.Currency {
+		return m, nil
+	}
+
+	fromExponent, err := Exponent(m.Currency)
+	if err != nil {
+		return Money{}, err
+	}
+	toExponent, err := Exponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	value := new(big.Rat).SetInt64(m.Amount)
+	value.Mul(value, rate)
+	value.Mul(value, new(big.Rat).SetFrac(pow10(toExponent), pow10(fromExponent)))
+
+	amount, err := round(value)
+	if err != nil {
+		return Money{}, fmt.Errorf("converted amount is too large")
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// Scale multiplies the amount by factor in the same currency, e.g. 4/5 for a 20% discount,
+// rounding half away from zero to the currency's minor units
+func (m Money) Scale(factor *big.Rat) (Money, error) {
+	value := new(big.Rat).SetInt64(m.Amount)
+	value.Mul(value, factor)
+
+	amount, err := round(value)
+	if err != nil {
+		return Money{}, fmt.Errorf("scaled amount is too large")
+	}
+
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// round rounds half away from zero: (2 * num + sign * den) / (2 * den), truncated
+func round(value *big.Rat) (int64, error) {
+	num := new(big.Int).Mul(value.Num(), big.NewInt(2))
+	num.Add(num, new(big.Int).Mul(value.Denom(), big.NewInt(int64(value.Sign()))))
+	den := new(big.Int).Mul(value.Denom(), big.NewInt(2))
+	amount := num.Quo(num, den)
+	if !amount.IsInt64() {
+		return 0, fmt.Errorf("amount is out of range")
+	}
+
+	return amount.Int64(), nil
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
@@ -0,0 +1,52 @@
+package slug
+
+import (
+	"fmt"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// MaxLength leaves room for a numbered suffix within a varchar(255) column
+const MaxLength = 200
+
+var (
+	separators = regexp.MustCompile(`[^a-z0-9]+`)
+	pattern    = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+	// letters that do not decompose into a base letter and accents
+	ligatures = strings.NewReplacer(
+		"ß", "ss", "æ", "ae", "Æ", "ae", "œ", "oe", "Œ", "oe", "ø", "o", "Ø", "o",
+		"đ", "d", "Đ", "d", "ł", "l", "Ł", "l", "þ", "th", "Þ", "th", "&", " and ",
+	)
+)
+
+// Make transliterates name to lowercase ASCII words joined by hyphens, so "Crème Brûlée & Co."
+// becomes "creme-brulee-and-co". Letters without an ASCII form are dropped, which can leave an
+// empty slug.
+func Make(name string) string {
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	ascii, _, err := transform.String(stripAccents, ligatures.Replace(name))
+	if err != nil {
+		ascii = name
+	}
+
+	s := strings.Trim(separators.ReplaceAllString(strings.ToLower(ascii), "-"), "-")
+	if len(s) > MaxLength {
+		s = strings.TrimRight(s[:MaxLength], "-")
+	}
+	return s
+}
+
+// Valid reports whether s is already a slug, lowercase letters and digits in hyphen separated words
+func Valid(s string) bool {
+	return pattern.MatchString(s)
+}
+
+// WithSuffix numbers a slug that is already taken, WithSuffix("sangcli", 2) is "sangcli-2"
+func WithSuffix(s string, n int) string {
+	return fmt.Sprintf("%s-%d", s, n)
+}
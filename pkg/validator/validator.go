@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"Unnispick/pkg/money"
+	"Unnispick/pkg/slug"
 	"context"
 	"fmt"
 	"github.com/go-playground/validator/v10"
@@ -10,6 +12,8 @@ import (
 
 type Validator struct {
 	validate *validator.Validate
+	// patch checks the fields of a partial update that carry patch rules
+	patch *validator.Validate
 }
 
 type ValidationError struct {
@@ -18,6 +22,16 @@ type ValidationError struct {
 }
 
 func NewValidator() *Validator {
+	patch := newValidate()
+	patch.SetTagName("patch")
+
+	return &Validator{
+		validate: newValidate(),
+		patch:    patch,
+	}
+}
+
+func newValidate() *validator.Validate {
 	v := validator.New()
 
 	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
@@ -31,10 +45,12 @@ func NewValidator() *Validator {
 	//Register custom validations
 	_ = v.RegisterValidation("price", validatePrice)
 	_ = v.RegisterValidation("quantity", validateQuantity)
+	_ = v.RegisterValidation("slug", validateSlug)
+	_ = v.RegisterValidation("country", func(fl validator.FieldLevel) bool {
+		return v.Var(strings.ToUpper(fl.Field().String()), "iso3166_1_alpha2") == nil
+	})
 
-	return &Validator{
-		validate: v,
-	}
+	return v
 }
 
 func (v *Validator) Validate(ctx context.Context, i interface{}) error {
@@ -45,6 +61,47 @@ func (v *Validator) ValidateVar(ctx context.Context, field interface{}, tag stri
 	return v.validate.VarCtx(ctx, field, tag)
 }
 
+// ValidateFields validates only the struct fields with the given JSON names, the ones a partial
+// update sets. A field with a patch tag is checked against those rules instead, for rules such as
+// required that reject a zero the patch sets on purpose.
+func (v *Validator) ValidateFields(ctx context.Context, i interface{}, fields ...string) error {
+	t := reflect.Indirect(reflect.ValueOf(i)).Type()
+
+	var names, patched []string
+	for _, field := range fields {
+		for n := 0; n < t.NumField(); n++ {
+			if strings.SplitN(t.Field(n).Tag.Get("json"), ",", 2)[0] != field {
+				continue
+			}
+			if _, ok := t.Field(n).Tag.Lookup("patch"); ok {
+				patched = append(patched, t.Field(n).Name)
+			} else {
+				names = append(names, t.Field(n).Name)
+			}
+		}
+	}
+
+	var failed validator.ValidationErrors
+	for _, check := range []struct {
+		validate *validator.Validate
+		names    []string
+	}{{v.validate, names}, {v.patch, patched}} {
+		if len(check.names) == 0 {
+			continue
+		}
+		err := check.validate.StructPartialCtx(ctx, i, check.names...)
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			failed = append(failed, errs...)
+		} else if err != nil {
+			return err
+		}
+	}
+	if len(failed) > 0 {
+		return failed
+	}
+	return nil
+}
+
 func (v *Validator) ExtractValidationErrors(err error) []ValidationError {
 	if err == nil {
 		return nil
@@ -70,21 +127,29 @@ func (v *Validator) generateValidationMessage(err validator.FieldError) string {
 		return fmt.Sprintf("Failed ! Value should be at least %s", err.Param())
 	case "max":
 		return fmt.Sprintf("Failed ! value should be at most %s", err.Param())
+	case "gte":
+		return fmt.Sprintf("Failed ! Value should be %s or greater", err.Param())
 	case "price":
-		return "Failed ! Price must be greater than 0"
+		return "Failed ! Price must be greater than 0 in a supported currency"
 	case "quantity":
 		return "Failed ! Quantity must be 0 or greater"
+	case "slug":
+		return "Failed ! Use lowercase letters, digits and single hyphens only"
+	case "country":
+		return "Failed ! Country must be an ISO 3166-1 alpha-2 code such as KR"
+	case "http_url":
+		return "Failed ! Invalid URL, it must start with http:// or https://"
 	default:
 		return fmt.Sprintf("Failed on %s validation", err.Tag())
 	}
 }
 
 func validatePrice(fl validator.FieldLevel) bool {
-	price, ok := fl.Field().Interface().(float64)
+	price, ok := fl.Field().Interface().(money.Money)
 	if !ok {
 		return false
 	}
-	return price > 0
+	return price.IsPositive() && money.IsSupported(price.Currency)
 }
 
 func validateQuantity(fl validator.FieldLevel) bool {
@@ -95,6 +160,10 @@ func validateQuantity(fl validator.FieldLevel) bool {
 	return quantity >= 0
 }
 
+func validateSlug(fl validator.FieldLevel) bool {
+	return slug.Valid(fl.Field().String())
+}
+
 func (v *Validator) ValidateID(ctx context.Context, id string) error {
 	return v.ValidateVar(ctx, id, "required,uuid")
 }
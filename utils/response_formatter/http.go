@@ -36,6 +36,24 @@ func Created(data interface{}, message string) Response {
 	}
 }
 
+func MovedPermanently(data interface{}, message string) Response {
+	return Response{
+		Code:    http.StatusMovedPermanently,
+		Message: message,
+		Data:    data,
+	}
+}
+
+// WithStatus wraps data in a response with a status other than the ones above, such as 207 for a
+// partly applied bulk request
+func WithStatus(code int, data interface{}, message string) Response {
+	return Response{
+		Code:    code,
+		Message: message,
+		Data:    data,
+	}
+}
+
 func Error(code int, message string, errors []string) Response {
 	return Response{
 		Code:    code,